@@ -0,0 +1,162 @@
+package faucet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// accountCache keeps the latest known state of the faucet accounts,
+// so drips don't need to query the chain for every faucet account.
+// Cached accounts are never modified, only replaced
+type accountCache struct {
+	accounts map[crypto.Address]std.Account // address -> account
+	mux      sync.RWMutex
+}
+
+// newAccountCache creates a new faucet account cache
+func newAccountCache() *accountCache {
+	return &accountCache{
+		accounts: make(map[crypto.Address]std.Account),
+	}
+}
+
+// get fetches the cached account, if any
+func (c *accountCache) get(address crypto.Address) (std.Account, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	account, exists := c.accounts[address]
+
+	return account, exists
+}
+
+// set caches the account
+func (c *accountCache) set(address crypto.Address, account std.Account) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.accounts[address] = account
+}
+
+// update replaces the cached account with the result of the update
+// function, atomically. The account is dropped if the update fails
+func (c *accountCache) update(address crypto.Address, updateFn func(std.Account) (std.Account, bool)) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	account, exists := c.accounts[address]
+	if !exists {
+		return
+	}
+
+	updated, ok := updateFn(account)
+	if !ok {
+		delete(c.accounts, address)
+
+		return
+	}
+
+	c.accounts[address] = updated
+}
+
+// invalidate drops the cached account, if any
+func (c *accountCache) invalidate(address crypto.Address) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	delete(c.accounts, address)
+}
+
+// getAccount fetches the faucet account, either from the cache or the chain
+func (f *Faucet) getAccount(ctx context.Context, address crypto.Address) (std.Account, error) {
+	if f.accountCache == nil {
+		return f.client.GetAccount(ctx, address)
+	}
+
+	if account, exists := f.accountCache.get(address); exists {
+		return account, nil
+	}
+
+	account, err := f.client.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	f.accountCache.set(address, account)
+
+	return account, nil
+}
+
+// applyTransfer optimistically updates the cached account
+// after a successful transfer, by deducting the spent funds
+// and bumping the used sequence, without querying the chain.
+// The spent funds are deducted from the current cached account
+// (not the account the transfer was funded from), since other
+// transfers may have updated it since. The caller needs to hold
+// the account sequence lock
+func (f *Faucet) applyTransfer(address crypto.Address, spent std.Coins, sequence uint64) {
+	if f.accountCache == nil {
+		return
+	}
+
+	f.accountCache.update(address, func(account std.Account) (std.Account, bool) {
+		// Make sure the cached account is at the transfer sequence,
+		// otherwise it doesn't reflect the transfers before it
+		if account.GetSequence() != sequence {
+			return nil, false
+		}
+
+		balance := account.GetCoins()
+
+		// Make sure the balance doesn't go negative,
+		// in case the cached account was already stale
+		if !balance.IsAllGTE(spent) {
+			return nil, false
+		}
+
+		return std.NewBaseAccount(
+			address,
+			balance.Sub(spent),
+			account.GetPubKey(),
+			account.GetAccountNumber(),
+			sequence+1,
+		), true
+	})
+}
+
+// refreshAccounts periodically refreshes the cached
+// faucet accounts from the chain, until the context is done [BLOCKING]
+func (f *Faucet) refreshAccounts(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, address := range f.keyring.GetAddresses() {
+				account, err := f.client.GetAccount(ctx, address)
+				if err != nil {
+					f.logger.Error(
+						"unable to refresh account",
+						"address",
+						address.String(),
+						"error",
+						err,
+					)
+
+					f.accountCache.invalidate(address)
+
+					continue
+				}
+
+				f.accountCache.set(address, account)
+			}
+		}
+	}
+}
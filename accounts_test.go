@@ -0,0 +1,224 @@
+package faucet
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gnolang/faucet/config"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountCache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		c       = newAccountCache()
+		address = crypto.Address{1}
+		account = std.NewBaseAccountWithAddress(address)
+	)
+
+	// Make sure the account is not cached initially
+	_, exists := c.get(address)
+	assert.False(t, exists)
+
+	// Cache the account
+	c.set(address, &account)
+
+	cached, exists := c.get(address)
+	require.True(t, exists)
+
+	assert.Equal(t, &account, cached)
+
+	// Invalidate the account
+	c.invalidate(address)
+
+	_, exists = c.get(address)
+	assert.False(t, exists)
+}
+
+// newCachingFaucet creates a faucet with account caching
+// enabled, and a single faucet account
+func newCachingFaucet(t *testing.T, client *mockClient) *Faucet {
+	t.Helper()
+
+	cfg := config.DefaultConfig()
+	cfg.AccountRefreshInterval = time.Hour
+
+	return newTestFaucet(t, cfg, client, nil)
+}
+
+func TestFaucet_AccountCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cached account updated after transfer", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			fetches    atomic.Int64
+			sendAmount = std.NewCoins(std.NewCoin("ugnot", 10))
+
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
+					fetches.Add(1)
+
+					return std.NewBaseAccount(
+						address,
+						std.NewCoins(std.NewCoin("ugnot", 100)),
+						nil,
+						0,
+						5,
+					), nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					return &coreTypes.ResultBroadcastTxCommit{}, nil
+				},
+			}
+		)
+
+		f := newCachingFaucet(t, mockClient)
+
+		// Execute two transfers
+		for range 2 {
+			_, err := f.transferFunds(context.Background(), crypto.Address{2}, sendAmount)
+			require.NoError(t, err)
+		}
+
+		// Make sure the account was fetched only once
+		assert.Equal(t, int64(1), fetches.Load())
+
+		// Make sure the cached account reflects both transfers
+		account, exists := f.accountCache.get(crypto.Address{1})
+		require.True(t, exists)
+
+		// 100 - 2 * (10 + 1 fee)
+		assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 78)), account.GetCoins())
+		assert.Equal(t, uint64(7), account.GetSequence())
+	})
+
+	t.Run("transfers applied to the current cached account", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			address = crypto.Address{1}
+			spent   = std.NewCoins(std.NewCoin("ugnot", 10))
+		)
+
+		f := newCachingFaucet(t, &mockClient{})
+
+		// Cache the account, as read by two concurrent drips
+		f.accountCache.set(address, std.NewBaseAccount(
+			address,
+			std.NewCoins(std.NewCoin("ugnot", 100)),
+			nil,
+			0,
+			5,
+		))
+
+		// Make sure the second drip deducts from the updated balance
+		f.applyTransfer(address, spent, 5)
+		f.applyTransfer(address, spent, 6)
+
+		account, exists := f.accountCache.get(address)
+		require.True(t, exists)
+
+		assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 80)), account.GetCoins())
+		assert.Equal(t, uint64(7), account.GetSequence())
+
+		// Make sure a transfer at a different sequence drops the account
+		f.applyTransfer(address, spent, 5)
+
+		_, exists = f.accountCache.get(address)
+		assert.False(t, exists)
+	})
+
+	t.Run("cached account invalidated after failed broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			fetches    atomic.Int64
+			sendAmount = std.NewCoins(std.NewCoin("ugnot", 10))
+
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
+					fetches.Add(1)
+
+					return std.NewBaseAccount(
+						address,
+						std.NewCoins(std.NewCoin("ugnot", 100)),
+						nil,
+						0,
+						0,
+					), nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					return nil, errors.New("unable to broadcast")
+				},
+			}
+		)
+
+		f := newCachingFaucet(t, mockClient)
+
+		_, err := f.transferFunds(context.Background(), crypto.Address{2}, sendAmount)
+		require.Error(t, err)
+
+		// Make sure the account is no longer cached
+		_, exists := f.accountCache.get(crypto.Address{1})
+		assert.False(t, exists)
+
+		// Make sure the next transfer fetches the account again
+		_, err = f.transferFunds(context.Background(), crypto.Address{2}, sendAmount)
+		require.Error(t, err)
+
+		assert.Equal(t, int64(2), fetches.Load())
+	})
+
+	t.Run("cached accounts refreshed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			balance atomic.Int64
+
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
+					return std.NewBaseAccount(
+						address,
+						std.NewCoins(std.NewCoin("ugnot", balance.Load())),
+						nil,
+						0,
+						0,
+					), nil
+				},
+			}
+		)
+
+		f := newCachingFaucet(t, mockClient)
+
+		// Cache the initial account state
+		balance.Store(100)
+
+		_, err := f.getAccount(context.Background(), crypto.Address{1})
+		require.NoError(t, err)
+
+		// Change the account state on the chain
+		balance.Store(200)
+
+		ctx, cancelFn := context.WithCancel(context.Background())
+		defer cancelFn()
+
+		go f.refreshAccounts(ctx, 10*time.Millisecond)
+
+		// Make sure the cached account is eventually refreshed
+		assert.Eventually(t, func() bool {
+			account, exists := f.accountCache.get(crypto.Address{1})
+
+			return exists && account.GetCoins().AmountOf("ugnot") == 200
+		}, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,182 @@
+package faucet
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// transfer is a single faucet fund transfer
+type transfer struct {
+	amount std.Coins      // the amount to be sent
+	to     crypto.Address // the beneficiary address
+	info   dripInfo       // the drip request information, if any
+}
+
+// executeTransfersFn executes the given transfers as a single transaction
+type executeTransfersFn func(ctx context.Context, transfers []transfer) (*txResult, error)
+
+// transferResult is the outcome of a batched transfer
+type transferResult struct {
+	err    error
+	result *txResult
+}
+
+// pendingTransfer is a transfer waiting for its batch to be executed
+type pendingTransfer struct {
+	ctx      context.Context // the drip request context
+	resultCh chan transferResult
+	transfer transfer
+}
+
+// batcher aggregates transfers that arrive within a short window,
+// and executes them together as a single multi-message transaction
+type batcher struct {
+	executeFn executeTransfersFn
+	timer     *time.Timer
+	pending   []*pendingTransfer
+
+	window  time.Duration // the period transfers are collected for
+	maxSize int           // the max number of transfers in a batch
+
+	mux sync.Mutex
+}
+
+// newBatcher creates a new transfer batcher
+func newBatcher(window time.Duration, maxSize int, executeFn executeTransfersFn) *batcher {
+	return &batcher{
+		executeFn: executeFn,
+		window:    window,
+		maxSize:   maxSize,
+	}
+}
+
+// submit adds the transfer to the current batch, and waits for the
+// batch to be executed [BLOCKING]. The batch transaction result is returned
+func (b *batcher) submit(ctx context.Context, t transfer) (*txResult, error) {
+	p := &pendingTransfer{
+		ctx:      ctx,
+		transfer: t,
+		resultCh: make(chan transferResult, 1),
+	}
+
+	b.mux.Lock()
+
+	b.pending = append(b.pending, p)
+
+	switch {
+	case len(b.pending) >= b.maxSize:
+		// The batch is full, execute it right away
+		go b.execute(b.take())
+	case len(b.pending) == 1:
+		// The batch is new, execute it once the window expires
+		b.timer = time.AfterFunc(b.window, b.flush)
+	}
+
+	b.mux.Unlock()
+
+	select {
+	case result := <-p.resultCh:
+		return result.result, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// flush executes the current batch
+func (b *batcher) flush() {
+	b.mux.Lock()
+	batch := b.take()
+	b.mux.Unlock()
+
+	b.execute(batch)
+}
+
+// take removes the current batch from the batcher.
+// The batcher lock needs to be held
+func (b *batcher) take() []*pendingTransfer {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	batch := b.pending
+	b.pending = nil
+
+	return batch
+}
+
+// execute executes the batch transfers as a single
+// transaction, and relays the result to each transfer.
+// Transfers whose context is already done are dropped from the batch
+func (b *batcher) execute(batch []*pendingTransfer) {
+	active := make([]*pendingTransfer, 0, len(batch))
+
+	for _, p := range batch {
+		if err := p.ctx.Err(); err != nil {
+			p.resultCh <- transferResult{
+				err: err,
+			}
+
+			continue
+		}
+
+		active = append(active, p)
+	}
+
+	if len(active) == 0 {
+		return
+	}
+
+	transfers := make([]transfer, 0, len(active))
+	for _, p := range active {
+		transfers = append(transfers, p.transfer)
+	}
+
+	ctx, cancelFn := batchContext(active)
+	defer cancelFn()
+
+	result, err := b.executeFn(ctx, transfers)
+
+	for _, p := range active {
+		p.resultCh <- transferResult{
+			result: result,
+			err:    err,
+		}
+	}
+}
+
+// batchContext creates the context the batch is executed with,
+// which is done once the contexts of all the batched transfers are done
+func batchContext(batch []*pendingTransfer) (context.Context, context.CancelFunc) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	var (
+		remaining atomic.Int64
+		stopFns   = make([]func() bool, 0, len(batch))
+	)
+
+	remaining.Store(int64(len(batch)))
+
+	for _, p := range batch {
+		stopFn := context.AfterFunc(p.ctx, func() {
+			if remaining.Add(-1) == 0 {
+				cancelFn()
+			}
+		})
+
+		stopFns = append(stopFns, stopFn)
+	}
+
+	return ctx, func() {
+		for _, stopFn := range stopFns {
+			stopFn()
+		}
+
+		cancelFn()
+	}
+}
@@ -0,0 +1,279 @@
+package faucet
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/keyring"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// submitTransfers submits the transfers to the batcher concurrently,
+// and waits for all of them to finish
+func submitTransfers(t *testing.T, b *batcher, transfers []transfer) []transferResult {
+	t.Helper()
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]transferResult, len(transfers))
+	)
+
+	for i, tr := range transfers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			result, err := b.submit(context.Background(), tr)
+
+			results[i] = transferResult{
+				result: result,
+				err:    err,
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func TestBatcher_Submit(t *testing.T) {
+	t.Parallel()
+
+	transfers := []transfer{
+		{to: crypto.Address{1}, amount: std.NewCoins(std.NewCoin("ugnot", 1))},
+		{to: crypto.Address{2}, amount: std.NewCoins(std.NewCoin("ugnot", 2))},
+		{to: crypto.Address{3}, amount: std.NewCoins(std.NewCoin("ugnot", 3))},
+	}
+
+	t.Run("batch executed after the window", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			batches [][]transfer
+			mux     sync.Mutex
+
+			txRes = &txResult{hash: []byte("hash")}
+		)
+
+		b := newBatcher(50*time.Millisecond, 10, func(_ context.Context, batch []transfer) (*txResult, error) {
+			mux.Lock()
+			defer mux.Unlock()
+
+			batches = append(batches, batch)
+
+			return txRes, nil
+		})
+
+		results := submitTransfers(t, b, transfers)
+
+		// Make sure all transfers were executed as a single batch
+		require.Len(t, batches, 1)
+		assert.ElementsMatch(t, transfers, batches[0])
+
+		for _, result := range results {
+			assert.NoError(t, result.err)
+			assert.Equal(t, txRes, result.result)
+		}
+	})
+
+	t.Run("batch executed when full", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			batches [][]transfer
+			mux     sync.Mutex
+		)
+
+		b := newBatcher(time.Hour, len(transfers), func(_ context.Context, batch []transfer) (*txResult, error) {
+			mux.Lock()
+			defer mux.Unlock()
+
+			batches = append(batches, batch)
+
+			return nil, nil
+		})
+
+		// The window never expires, so the batch
+		// is executed only because it's full
+		submitTransfers(t, b, transfers)
+
+		require.Len(t, batches, 1)
+		assert.Len(t, batches[0], len(transfers))
+	})
+
+	t.Run("batch error relayed", func(t *testing.T) {
+		t.Parallel()
+
+		executeErr := errors.New("unable to execute")
+
+		b := newBatcher(10*time.Millisecond, 10, func(_ context.Context, _ []transfer) (*txResult, error) {
+			return nil, executeErr
+		})
+
+		results := submitTransfers(t, b, transfers)
+
+		for _, result := range results {
+			assert.ErrorIs(t, result.err, executeErr)
+		}
+	})
+
+	t.Run("canceled transfer dropped", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			batches [][]transfer
+			mux     sync.Mutex
+		)
+
+		b := newBatcher(50*time.Millisecond, 10, func(_ context.Context, batch []transfer) (*txResult, error) {
+			mux.Lock()
+			defer mux.Unlock()
+
+			batches = append(batches, batch)
+
+			return nil, nil
+		})
+
+		// Submit a transfer that is canceled before the window expires
+		ctx, cancelFn := context.WithCancel(context.Background())
+		cancelFn()
+
+		_, err := b.submit(ctx, transfers[0])
+		assert.ErrorIs(t, err, context.Canceled)
+
+		// Submit the remaining transfers
+		submitTransfers(t, b, transfers[1:])
+
+		// Make sure the canceled transfer was not executed
+		mux.Lock()
+		defer mux.Unlock()
+
+		require.Len(t, batches, 1)
+		assert.ElementsMatch(t, transfers[1:], batches[0])
+	})
+}
+
+func TestBatchContext(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctxA, cancelA = context.WithCancel(context.Background())
+		ctxB, cancelB = context.WithCancel(context.Background())
+
+		batch = []*pendingTransfer{
+			{ctx: ctxA},
+			{ctx: ctxB},
+		}
+	)
+
+	defer cancelB()
+
+	ctx, cancelFn := batchContext(batch)
+	defer cancelFn()
+
+	// Make sure the batch context is active
+	// while any of the transfer contexts is
+	cancelA()
+
+	assert.Never(t, func() bool {
+		return ctx.Err() != nil
+	}, 50*time.Millisecond, time.Millisecond)
+
+	// Make sure the batch context is done
+	// once all the transfer contexts are
+	cancelB()
+
+	assert.Eventually(t, func() bool {
+		return ctx.Err() != nil
+	}, time.Second, time.Millisecond)
+}
+
+func TestFaucet_TransferFunds_Batch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTransfers = 3
+		sendAmount   = std.NewCoins(std.NewCoin("ugnot", 10))
+
+		capturedTxs []*std.Tx
+		mux         sync.Mutex
+
+		mockClient = &mockClient{
+			getAccountFn: func(_ context.Context, _ crypto.Address) (std.Account, error) {
+				return &mockAccount{
+					getCoinsFn: func() std.Coins {
+						return std.NewCoins(std.NewCoin("ugnot", 1000))
+					},
+				}, nil
+			},
+			sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+				mux.Lock()
+				defer mux.Unlock()
+
+				capturedTxs = append(capturedTxs, tx)
+
+				return &coreTypes.ResultBroadcastTxCommit{}, nil
+			},
+		}
+		mockEstimator = &mockEstimator{
+			estimateGasFeeFn: func(_ int64) std.Coin {
+				return std.NewCoin("ugnot", 1)
+			},
+		}
+		mockKeyring = &mockKeyring{
+			getSignerFn: func(_ crypto.Address) keyring.Signer {
+				return &mockSigner{}
+			},
+			getAddressesFn: func() []crypto.Address {
+				return []crypto.Address{{0}}
+			},
+		}
+	)
+
+	cfg := config.DefaultConfig()
+	cfg.MaxSendAmount = sendAmount.String()
+	cfg.BatchWindow = 50 * time.Millisecond
+
+	f, err := NewFaucet(mockEstimator, mockClient, WithConfig(cfg), WithKeyring(mockKeyring))
+	require.NoError(t, err)
+
+	// Run the transfers in parallel
+	var wg sync.WaitGroup
+
+	for i := range numTransfers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			//nolint:gosec // i is small
+			_, transferErr := f.transferFunds(context.Background(), crypto.Address{byte(i + 1)}, sendAmount)
+			assert.NoError(t, transferErr)
+		}()
+	}
+
+	wg.Wait()
+
+	// Make sure a single transaction was sent,
+	// with a message for each transfer
+	require.Len(t, capturedTxs, 1)
+	require.Len(t, capturedTxs[0].Msgs, numTransfers)
+
+	for _, msg := range capturedTxs[0].Msgs {
+		msgSend, ok := msg.(bank.MsgSend)
+		require.True(t, ok)
+
+		assert.Equal(t, sendAmount, msgSend.Amount)
+	}
+}
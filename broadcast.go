@@ -1,31 +1,65 @@
 package faucet
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/gnolang/faucet/client"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
+var (
+	errCheckTxFailed   = errors.New("transaction failed initial validation")
+	errDeliverTxFailed = errors.New("transaction failed during execution")
+)
+
 // broadcastTransaction broadcasts the transaction using a COMMIT send
-func broadcastTransaction(client client.Client, tx *std.Tx) error {
+func broadcastTransaction(
+	ctx context.Context,
+	client client.Client,
+	tx *std.Tx,
+) (*coreTypes.ResultBroadcastTxCommit, error) {
 	// Send the transaction.
-	// NOTE: Commit sends are temporary. Once
-	// there is support for event indexing, this
-	// call will change to a sync send
-	response, err := client.SendTransactionCommit(tx)
+	// NOTE: the WS client (client/ws) implements commit
+	// sends as a sync send, followed by a wait for the
+	// transaction inclusion
+	response, err := client.SendTransactionCommit(ctx, tx)
 	if err != nil {
-		return fmt.Errorf("unable to send transaction, %w", err)
+		return nil, fmt.Errorf("unable to send transaction, %w", err)
 	}
 
 	// Check the errors
 	if response.CheckTx.IsErr() {
-		return fmt.Errorf("transaction failed initial validation, %w", response.CheckTx.Error)
+		return nil, fmt.Errorf("%w, %w", errCheckTxFailed, response.CheckTx.Error)
 	}
 
 	if response.DeliverTx.IsErr() {
-		return fmt.Errorf("transaction failed during execution, %w", response.DeliverTx.Error)
+		return nil, fmt.Errorf("%w, %w", errDeliverTxFailed, response.DeliverTx.Error)
+	}
+
+	return response, nil
+}
+
+// broadcastTransactionSync broadcasts the transaction using a SYNC send,
+// and returns once the transaction passes the initial validation
+func broadcastTransactionSync(
+	ctx context.Context,
+	client client.Client,
+	tx *std.Tx,
+) (*coreTypes.ResultBroadcastTx, error) {
+	// Send the transaction, without
+	// waiting for it to be committed
+	response, err := client.SendTransactionSync(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send transaction, %w", err)
+	}
+
+	// Check the errors
+	if response.Error != nil {
+		return nil, fmt.Errorf("%w, %w", errCheckTxFailed, response.Error)
 	}
 
-	return nil
+	return response, nil
 }
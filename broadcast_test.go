@@ -1,6 +1,7 @@
 package faucet
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -24,7 +25,7 @@ func TestBroadcastTransaction(t *testing.T) {
 			capturedTx *std.Tx
 
 			mockClient = &mockClient{
-				sendTransactionCommitFn: func(tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+				sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
 					capturedTx = tx
 
 					return nil, sendErr
@@ -34,7 +35,8 @@ func TestBroadcastTransaction(t *testing.T) {
 
 		// Broadcast the transaction, and capture the error
 		tx := &std.Tx{Memo: "dummy tx"}
-		require.ErrorIs(t, broadcastTransaction(mockClient, tx), sendErr)
+		_, err := broadcastTransaction(context.Background(), mockClient, tx)
+		require.ErrorIs(t, err, sendErr)
 
 		// Make sure the correct transaction
 		// broadcast was attempted
@@ -57,7 +59,7 @@ func TestBroadcastTransaction(t *testing.T) {
 			}
 
 			mockClient = &mockClient{
-				sendTransactionCommitFn: func(tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+				sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
 					capturedTx = tx
 
 					return response, nil
@@ -67,7 +69,8 @@ func TestBroadcastTransaction(t *testing.T) {
 
 		// Broadcast the transaction, and capture the error
 		tx := &std.Tx{Memo: "dummy tx"}
-		require.ErrorIs(t, broadcastTransaction(mockClient, tx), checkTxErr)
+		_, err := broadcastTransaction(context.Background(), mockClient, tx)
+		require.ErrorIs(t, err, checkTxErr)
 
 		// Make sure the correct transaction
 		// broadcast was attempted
@@ -90,7 +93,7 @@ func TestBroadcastTransaction(t *testing.T) {
 			}
 
 			mockClient = &mockClient{
-				sendTransactionCommitFn: func(tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+				sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
 					capturedTx = tx
 
 					return response, nil
@@ -100,7 +103,8 @@ func TestBroadcastTransaction(t *testing.T) {
 
 		// Broadcast the transaction, and capture the error
 		tx := &std.Tx{Memo: "dummy tx"}
-		require.ErrorIs(t, broadcastTransaction(mockClient, tx), deliverTxErr)
+		_, err := broadcastTransaction(context.Background(), mockClient, tx)
+		require.ErrorIs(t, err, deliverTxErr)
 
 		// Make sure the correct transaction
 		// broadcast was attempted
@@ -127,7 +131,7 @@ func TestBroadcastTransaction(t *testing.T) {
 			}
 
 			mockClient = &mockClient{
-				sendTransactionCommitFn: func(tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+				sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
 					capturedTx = tx
 
 					return response, nil
@@ -137,10 +141,89 @@ func TestBroadcastTransaction(t *testing.T) {
 
 		// Broadcast the transaction, and capture the error
 		tx := &std.Tx{Memo: "dummy tx"}
-		require.NoError(t, broadcastTransaction(mockClient, tx))
+		result, err := broadcastTransaction(context.Background(), mockClient, tx)
+		require.NoError(t, err)
 
 		// Make sure the correct transaction
 		// broadcast was attempted
 		assert.Equal(t, tx, capturedTx)
+		assert.Equal(t, response, result)
+	})
+}
+
+func TestBroadcastTransactionSync(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			sendErr = errors.New("unable to send transaction")
+
+			mockClient = &mockClient{
+				sendTransactionSyncFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+					return nil, sendErr
+				},
+			}
+		)
+
+		// Broadcast the transaction, and capture the error
+		response, err := broadcastTransactionSync(context.Background(), mockClient, &std.Tx{Memo: "dummy tx"})
+		require.ErrorIs(t, err, sendErr)
+
+		assert.Nil(t, response)
+	})
+
+	t.Run("initial tx validation error (CheckTx)", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			checkTxErr = tm2Errors.UnauthorizedError{}
+
+			mockClient = &mockClient{
+				sendTransactionSyncFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+					return &coreTypes.ResultBroadcastTx{
+						Error: checkTxErr,
+					}, nil
+				},
+			}
+		)
+
+		// Broadcast the transaction, and capture the error
+		response, err := broadcastTransactionSync(context.Background(), mockClient, &std.Tx{Memo: "dummy tx"})
+		require.ErrorIs(t, err, checkTxErr)
+		require.ErrorIs(t, err, errCheckTxFailed)
+
+		assert.Nil(t, response)
+	})
+
+	t.Run("valid broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			capturedTx *std.Tx
+			txHash     = []byte("hash")
+
+			mockClient = &mockClient{
+				sendTransactionSyncFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+					capturedTx = tx
+
+					return &coreTypes.ResultBroadcastTx{
+						Hash: txHash,
+					}, nil
+				},
+			}
+		)
+
+		// Broadcast the transaction
+		tx := &std.Tx{Memo: "dummy tx"}
+
+		response, err := broadcastTransactionSync(context.Background(), mockClient, tx)
+		require.NoError(t, err)
+
+		// Make sure the correct transaction
+		// broadcast was attempted
+		assert.Equal(t, tx, capturedTx)
+		assert.Equal(t, txHash, response.Hash)
 	})
 }
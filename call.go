@@ -0,0 +1,63 @@
+package faucet
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// NewRealmCallMessageFn creates a drip message constructor that calls
+// the given realm function (vm.MsgCall). Each call argument is a template,
+// rendered with the drip PrepareCfg (for example {{.ToAddress}},
+// or {{.SendAmount.AmountOf "utest"}}). If attachAmount is set,
+// the drip amount is sent along with the call
+func NewRealmCallMessageFn(
+	pkgPath,
+	function string,
+	args []string,
+	attachAmount bool,
+) (PrepareTxMessageFn, error) {
+	argTemplates := make([]*template.Template, 0, len(args))
+
+	for i, arg := range args {
+		tmpl, err := template.New(fmt.Sprintf("arg%d", i)).Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid call argument %d, %w", i, err)
+		}
+
+		// Make sure the argument only references the available drip data
+		if err := tmpl.Execute(&strings.Builder{}, PrepareCfg{}); err != nil {
+			return nil, fmt.Errorf("invalid call argument %d, %w", i, err)
+		}
+
+		argTemplates = append(argTemplates, tmpl)
+	}
+
+	return func(cfg PrepareCfg) std.Msg {
+		callArgs := make([]string, 0, len(argTemplates))
+
+		for _, tmpl := range argTemplates {
+			var arg strings.Builder
+
+			_ = tmpl.Execute(&arg, cfg) //nolint:errcheck // validated beforehand
+
+			callArgs = append(callArgs, arg.String())
+		}
+
+		msg := vm.MsgCall{
+			Caller:  cfg.FromAddress,
+			PkgPath: pkgPath,
+			Func:    function,
+			Args:    callArgs,
+		}
+
+		if attachAmount {
+			msg.Send = cfg.SendAmount
+		}
+
+		return msg
+	}, nil
+}
@@ -0,0 +1,154 @@
+package faucet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRealmCallMessageFn(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pkgPath  = "gno.land/r/demo/foo20"
+		function = "Transfer"
+
+		cfg = PrepareCfg{
+			FromAddress: crypto.Address{1},
+			ToAddress:   crypto.Address{2},
+			SendAmount: std.NewCoins(
+				std.NewCoin("ugnot", 10),
+				std.NewCoin("utest", 20),
+			),
+		}
+	)
+
+	t.Run("invalid argument template", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewRealmCallMessageFn(pkgPath, function, []string{"{{.ToAddress"}, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown argument field", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewRealmCallMessageFn(pkgPath, function, []string{"{{.Unknown}}"}, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("arguments rendered", func(t *testing.T) {
+		t.Parallel()
+
+		prepareFn, err := NewRealmCallMessageFn(
+			pkgPath,
+			function,
+			[]string{"{{.ToAddress}}", `{{.SendAmount.AmountOf "utest"}}`, "static"},
+			false,
+		)
+		require.NoError(t, err)
+
+		msg, ok := prepareFn(cfg).(vm.MsgCall)
+		require.True(t, ok)
+
+		assert.Equal(t, cfg.FromAddress, msg.Caller)
+		assert.Equal(t, pkgPath, msg.PkgPath)
+		assert.Equal(t, function, msg.Func)
+		assert.Equal(t, []string{cfg.ToAddress.String(), "20", "static"}, msg.Args)
+		assert.Empty(t, msg.Send)
+	})
+
+	t.Run("amount attached", func(t *testing.T) {
+		t.Parallel()
+
+		prepareFn, err := NewRealmCallMessageFn(pkgPath, function, nil, true)
+		require.NoError(t, err)
+
+		msg, ok := prepareFn(cfg).(vm.MsgCall)
+		require.True(t, ok)
+
+		assert.Equal(t, cfg.SendAmount, msg.Send)
+		assert.Empty(t, msg.Args)
+	})
+}
+
+func TestFaucet_RealmCall(t *testing.T) {
+	t.Parallel()
+
+	var (
+		capturedTxs []*std.Tx
+
+		fee = std.NewCoin("ugnot", 1)
+
+		mockEstimator = &mockEstimator{
+			estimateGasFeeFn: func(_ int64) std.Coin {
+				return fee
+			},
+		}
+		mockKeyring = &mockKeyring{
+			getSignerFn: func(_ crypto.Address) keyring.Signer {
+				return &mockSigner{}
+			},
+			getAddressesFn: func() []crypto.Address {
+				return []crypto.Address{{1}}
+			},
+		}
+		mockClient = &mockClient{
+			getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
+				// The account only covers the fee
+				return std.NewBaseAccount(
+					address,
+					std.NewCoins(fee),
+					nil,
+					0,
+					0,
+				), nil
+			},
+			sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+				capturedTxs = append(capturedTxs, tx)
+
+				return &coreTypes.ResultBroadcastTxCommit{}, nil
+			},
+		}
+	)
+
+	cfg := config.DefaultConfig()
+	cfg.DripMessage = config.DripMessageCall
+	cfg.RealmCall = &config.RealmCall{
+		PkgPath: "gno.land/r/demo/foo20",
+		Func:    "Transfer",
+		Args:    []string{"{{.ToAddress}}", `{{.SendAmount.AmountOf "ugnot"}}`},
+	}
+
+	f, err := NewFaucet(mockEstimator, mockClient, WithConfig(cfg), WithKeyring(mockKeyring))
+	require.NoError(t, err)
+
+	beneficiary := crypto.Address{2}
+
+	_, err = f.transferFunds(
+		context.Background(),
+		beneficiary,
+		std.NewCoins(std.NewCoin("ugnot", 100)),
+	)
+	require.NoError(t, err)
+
+	// Make sure the realm call was sent
+	require.Len(t, capturedTxs, 1)
+	require.Len(t, capturedTxs[0].Msgs, 1)
+
+	msg, ok := capturedTxs[0].Msgs[0].(vm.MsgCall)
+	require.True(t, ok)
+
+	assert.Equal(t, cfg.RealmCall.PkgPath, msg.PkgPath)
+	assert.Equal(t, cfg.RealmCall.Func, msg.Func)
+	assert.Equal(t, []string{beneficiary.String(), "100"}, msg.Args)
+	assert.Empty(t, msg.Send)
+}
@@ -1,28 +1,45 @@
 package client
 
 import (
+	"context"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
-// Client defines the TM2 client functionality
+// Client defines the TM2 client functionality.
+// The given context bounds each call to the node
 type Client interface {
-	// Account methods //
+	// Account and network methods //
 
 	// GetAccount fetches the account if it has been initialized
-	GetAccount(address crypto.Address) (std.Account, error)
+	GetAccount(ctx context.Context, address crypto.Address) (std.Account, error)
+
+	// GetGasPrice fetches the gas price of the latest block
+	GetGasPrice(ctx context.Context) (std.GasPrice, error)
 
 	// Transaction methods //
 
+	// SimulateTransaction runs the specified transaction through the node's
+	// simulation, without committing it to the chain. The execution errors,
+	// if any, are part of the returned result
+	SimulateTransaction(ctx context.Context, tx *std.Tx) (*abci.ResponseDeliverTx, error)
+
 	// SendTransactionSync sends the specified transaction to the network,
 	// and does not wait for it to be committed to the chain
-	SendTransactionSync(tx *std.Tx) (*coreTypes.ResultBroadcastTx, error)
+	SendTransactionSync(ctx context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTx, error)
 
 	// SendTransactionCommit sends the specified transaction to the network,
 	// and wait for it to be committed to the chain
-	SendTransactionCommit(tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error)
+	SendTransactionCommit(ctx context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error)
+
+	// GetTransaction fetches the result of the committed transaction
+	// with the specified hash. Transactions that are not yet committed
+	// are reported as an error
+	GetTransaction(ctx context.Context, hash []byte) (*coreTypes.ResultTx, error)
 
 	// Status fetches the node's latest status
-	Status() (*coreTypes.ResultStatus, error)
+	Status(ctx context.Context) (*coreTypes.ResultStatus, error)
 }
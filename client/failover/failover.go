@@ -0,0 +1,257 @@
+package failover
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gnolang/faucet/client"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const (
+	DefaultInterval = 5 * time.Second
+	DefaultTimeout  = 5 * time.Second
+)
+
+var (
+	errNoClients  = errors.New("no clients provided")
+	errCatchingUp = errors.New("node is catching up")
+)
+
+// endpoint is a single node client, along with its latest health
+type endpoint struct {
+	client client.Client
+
+	healthy bool  // the flag indicating if the node passed the latest check
+	height  int64 // the node's latest block height
+}
+
+// Client is a TM2 client that spreads over multiple nodes.
+// The nodes are periodically health-checked (through Status), and calls
+// are routed to the healthiest node (the highest block height, not catching
+// up). Queries fail over to the next healthiest node on errors. Transaction
+// sends are never retried on another node, since broadcasting the same
+// signed transaction twice can't be told apart from a sequence mismatch.
+// Instead, the failing node is demoted until its next health check
+type Client struct {
+	endpoints []*endpoint
+	mux       sync.RWMutex
+
+	interval time.Duration // the node health check interval
+	timeout  time.Duration // the node health check timeout
+}
+
+// New creates a new failover client, for the given node clients.
+// Until the nodes are first checked, they are tried in the given order
+func New(clients []client.Client, opts ...Option) (*Client, error) {
+	if len(clients) == 0 {
+		return nil, errNoClients
+	}
+
+	endpoints := make([]*endpoint, 0, len(clients))
+
+	for _, c := range clients {
+		endpoints = append(endpoints, &endpoint{
+			client:  c,
+			healthy: true,
+		})
+	}
+
+	c := &Client{
+		endpoints: endpoints,
+		interval:  DefaultInterval,
+		timeout:   DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Run periodically health-checks the nodes,
+// until the context is done [BLOCKING]
+func (c *Client) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.checkHealth(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Client) GetAccount(ctx context.Context, address crypto.Address) (std.Account, error) {
+	return query(ctx, c, func(cl client.Client) (std.Account, error) {
+		return cl.GetAccount(ctx, address)
+	})
+}
+
+func (c *Client) GetGasPrice(ctx context.Context) (std.GasPrice, error) {
+	return query(ctx, c, func(cl client.Client) (std.GasPrice, error) {
+		return cl.GetGasPrice(ctx)
+	})
+}
+
+func (c *Client) SimulateTransaction(ctx context.Context, tx *std.Tx) (*abci.ResponseDeliverTx, error) {
+	return query(ctx, c, func(cl client.Client) (*abci.ResponseDeliverTx, error) {
+		return cl.SimulateTransaction(ctx, tx)
+	})
+}
+
+func (c *Client) SendTransactionSync(ctx context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+	return send(c, func(cl client.Client) (*coreTypes.ResultBroadcastTx, error) {
+		return cl.SendTransactionSync(ctx, tx)
+	})
+}
+
+func (c *Client) SendTransactionCommit(ctx context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+	return send(c, func(cl client.Client) (*coreTypes.ResultBroadcastTxCommit, error) {
+		return cl.SendTransactionCommit(ctx, tx)
+	})
+}
+
+func (c *Client) GetTransaction(ctx context.Context, hash []byte) (*coreTypes.ResultTx, error) {
+	return query(ctx, c, func(cl client.Client) (*coreTypes.ResultTx, error) {
+		return cl.GetTransaction(ctx, hash)
+	})
+}
+
+func (c *Client) Status(ctx context.Context) (*coreTypes.ResultStatus, error) {
+	return query(ctx, c, func(cl client.Client) (*coreTypes.ResultStatus, error) {
+		return cl.Status(ctx)
+	})
+}
+
+// query runs the query on the healthiest node, failing over to the next
+// healthiest nodes on errors. Nodes that fail a query that a later node
+// serves are demoted, while queries that fail on every node (for example,
+// a transaction that's not committed yet) leave the nodes as they are
+func query[T any](ctx context.Context, c *Client, queryFn func(client.Client) (T, error)) (T, error) {
+	var (
+		failed []*endpoint
+		errs   []error
+	)
+
+	for _, e := range c.ranked() {
+		result, err := queryFn(e.client)
+		if err == nil {
+			c.demote(failed...)
+
+			return result, nil
+		}
+
+		failed = append(failed, e)
+		errs = append(errs, err)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	var empty T
+
+	return empty, errors.Join(errs...)
+}
+
+// send runs the transaction send on the healthiest node only.
+// The node is demoted if the send fails
+func send[T any](c *Client, sendFn func(client.Client) (T, error)) (T, error) {
+	e := c.ranked()[0]
+
+	result, err := sendFn(e.client)
+	if err != nil {
+		c.demote(e)
+	}
+
+	return result, err
+}
+
+// ranked returns the nodes, healthiest first: the healthy nodes by
+// block height (highest first), followed by the unhealthy nodes
+func (c *Client) ranked() []*endpoint {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	ranked := slices.Clone(c.endpoints)
+
+	slices.SortStableFunc(ranked, func(a, b *endpoint) int {
+		if a.healthy != b.healthy {
+			if a.healthy {
+				return -1
+			}
+
+			return 1
+		}
+
+		return cmp.Compare(b.height, a.height)
+	})
+
+	return ranked
+}
+
+// demote marks the nodes as unhealthy, until their next health check
+func (c *Client) demote(endpoints ...*endpoint) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	for _, e := range endpoints {
+		e.healthy = false
+	}
+}
+
+// checkHealth fetches the status of every node (concurrently),
+// and updates the node health
+func (c *Client) checkHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, e := range c.endpoints {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			height, err := c.fetchHeight(ctx, e.client)
+
+			c.mux.Lock()
+			defer c.mux.Unlock()
+
+			e.healthy = err == nil
+			e.height = height
+		}()
+	}
+
+	wg.Wait()
+}
+
+// fetchHeight fetches the node's latest block height,
+// if the node is not catching up
+func (c *Client) fetchHeight(ctx context.Context, cl client.Client) (int64, error) {
+	ctx, cancelFn := context.WithTimeout(ctx, c.timeout)
+	defer cancelFn()
+
+	status, err := cl.Status(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to fetch node status, %w", err)
+	}
+
+	if status.SyncInfo.CatchingUp {
+		return 0, errCatchingUp
+	}
+
+	return status.SyncInfo.LatestBlockHeight, nil
+}
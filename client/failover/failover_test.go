@@ -0,0 +1,197 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gnolang/faucet/client"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStatusFn creates a status delegate for a node at the given height
+func newStatusFn(height int64, catchingUp bool) statusDelegate {
+	return func(_ context.Context) (*coreTypes.ResultStatus, error) {
+		return &coreTypes.ResultStatus{
+			SyncInfo: coreTypes.SyncInfo{
+				LatestBlockHeight: height,
+				CatchingUp:        catchingUp,
+			},
+		}, nil
+	}
+}
+
+// newAccountFn creates an account delegate that counts its calls,
+// and returns the given error, if any
+func newAccountFn(calls *atomic.Int64, err error) getAccountDelegate {
+	return func(_ context.Context, address crypto.Address) (std.Account, error) {
+		calls.Add(1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return std.NewBaseAccount(address, nil, nil, 0, 0), nil
+	}
+}
+
+func TestClient_New(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(nil)
+	assert.ErrorIs(t, err, errNoClients)
+}
+
+func TestClient_HealthiestRouting(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lagging, catchingUp, healthiest atomic.Int64
+
+		clients = []client.Client{
+			&mockClient{
+				statusFn:     newStatusFn(10, false),
+				getAccountFn: newAccountFn(&lagging, nil),
+			},
+			&mockClient{
+				statusFn:     newStatusFn(30, true),
+				getAccountFn: newAccountFn(&catchingUp, nil),
+			},
+			&mockClient{
+				statusFn:     newStatusFn(20, false),
+				getAccountFn: newAccountFn(&healthiest, nil),
+			},
+		}
+	)
+
+	c, err := New(clients)
+	require.NoError(t, err)
+
+	c.checkHealth(context.Background())
+
+	_, err = c.GetAccount(context.Background(), crypto.Address{1})
+	require.NoError(t, err)
+
+	// Make sure the highest node that's not catching up was used
+	assert.Equal(t, int64(1), healthiest.Load())
+	assert.Zero(t, lagging.Load())
+	assert.Zero(t, catchingUp.Load())
+}
+
+func TestClient_QueryFailover(t *testing.T) {
+	t.Parallel()
+
+	t.Run("failing node demoted", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			primary, backup atomic.Int64
+
+			clients = []client.Client{
+				&mockClient{
+					getAccountFn: newAccountFn(&primary, errors.New("connection refused")),
+				},
+				&mockClient{
+					getAccountFn: newAccountFn(&backup, nil),
+				},
+			}
+		)
+
+		c, err := New(clients)
+		require.NoError(t, err)
+
+		// Make sure the query failed over to the backup node
+		_, err = c.GetAccount(context.Background(), crypto.Address{1})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), primary.Load())
+		assert.Equal(t, int64(1), backup.Load())
+
+		// Make sure the failing node was demoted
+		_, err = c.GetAccount(context.Background(), crypto.Address{1})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), primary.Load())
+		assert.Equal(t, int64(2), backup.Load())
+	})
+
+	t.Run("query failed on every node", func(t *testing.T) {
+		t.Parallel()
+
+		errNotFound := errors.New("tx not found")
+
+		getTransactionFn := func(_ context.Context, _ []byte) (*coreTypes.ResultTx, error) {
+			return nil, errNotFound
+		}
+
+		c, err := New([]client.Client{
+			&mockClient{getTransactionFn: getTransactionFn},
+			&mockClient{getTransactionFn: getTransactionFn},
+		})
+		require.NoError(t, err)
+
+		_, err = c.GetTransaction(context.Background(), []byte("hash"))
+		assert.ErrorIs(t, err, errNotFound)
+
+		// Make sure the nodes were not demoted
+		for _, e := range c.endpoints {
+			assert.True(t, e.healthy)
+		}
+	})
+}
+
+func TestClient_SendTransaction(t *testing.T) {
+	t.Parallel()
+
+	var (
+		primary, backup atomic.Int64
+
+		errSend = errors.New("connection reset")
+
+		clients = []client.Client{
+			&mockClient{
+				sendTransactionSyncFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+					primary.Add(1)
+
+					return nil, errSend
+				},
+			},
+			&mockClient{
+				sendTransactionSyncFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+					backup.Add(1)
+
+					return &coreTypes.ResultBroadcastTx{}, nil
+				},
+			},
+		}
+	)
+
+	c, err := New(clients)
+	require.NoError(t, err)
+
+	// Make sure the failed send was not rebroadcast to the backup node
+	_, err = c.SendTransactionSync(context.Background(), &std.Tx{})
+	assert.ErrorIs(t, err, errSend)
+
+	assert.Equal(t, int64(1), primary.Load())
+	assert.Zero(t, backup.Load())
+
+	// Make sure the next send goes to the backup node
+	_, err = c.SendTransactionSync(context.Background(), &std.Tx{})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), primary.Load())
+	assert.Equal(t, int64(1), backup.Load())
+
+	// Make sure the primary node is restored once healthy again
+	c.checkHealth(context.Background())
+
+	for _, e := range c.endpoints {
+		assert.True(t, e.healthy)
+	}
+}
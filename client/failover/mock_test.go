@@ -0,0 +1,68 @@
+package failover
+
+import (
+	"context"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type (
+	getAccountDelegate          func(context.Context, crypto.Address) (std.Account, error)
+	sendTransactionSyncDelegate func(context.Context, *std.Tx) (*coreTypes.ResultBroadcastTx, error)
+	getTransactionDelegate      func(context.Context, []byte) (*coreTypes.ResultTx, error)
+	statusDelegate              func(context.Context) (*coreTypes.ResultStatus, error)
+)
+
+type mockClient struct {
+	getAccountFn          getAccountDelegate
+	sendTransactionSyncFn sendTransactionSyncDelegate
+	getTransactionFn      getTransactionDelegate
+	statusFn              statusDelegate
+}
+
+func (m *mockClient) GetAccount(ctx context.Context, address crypto.Address) (std.Account, error) {
+	if m.getAccountFn != nil {
+		return m.getAccountFn(ctx, address)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) GetGasPrice(_ context.Context) (std.GasPrice, error) {
+	return std.GasPrice{}, nil
+}
+
+func (m *mockClient) SimulateTransaction(_ context.Context, _ *std.Tx) (*abci.ResponseDeliverTx, error) {
+	return nil, nil
+}
+
+func (m *mockClient) SendTransactionSync(ctx context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+	if m.sendTransactionSyncFn != nil {
+		return m.sendTransactionSyncFn(ctx, tx)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) SendTransactionCommit(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+	return nil, nil
+}
+
+func (m *mockClient) GetTransaction(ctx context.Context, hash []byte) (*coreTypes.ResultTx, error) {
+	if m.getTransactionFn != nil {
+		return m.getTransactionFn(ctx, hash)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) Status(ctx context.Context) (*coreTypes.ResultStatus, error) {
+	if m.statusFn != nil {
+		return m.statusFn(ctx)
+	}
+
+	return &coreTypes.ResultStatus{}, nil
+}
@@ -0,0 +1,19 @@
+package failover
+
+import "time"
+
+type Option func(c *Client)
+
+// WithInterval specifies the node health check interval
+func WithInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.interval = interval
+	}
+}
+
+// WithTimeout specifies the node health check timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
@@ -5,12 +5,18 @@ import (
 	"fmt"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	rpcClient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
+const (
+	simulatePath = ".app/simulate" // the ABCI query path for transaction simulation
+	gasPricePath = "auth/gasprice" // the ABCI query path for the latest gas price
+)
+
 // Client is the TM2 HTTP client
 type Client struct {
 	client rpcClient.Client
@@ -23,15 +29,21 @@ func NewClient(remote string) (*Client, error) {
 		return nil, fmt.Errorf("unable to create HTTP client, %w", err)
 	}
 
+	return NewClientFromRPC(client), nil
+}
+
+// NewClientFromRPC creates a new TM2 client, using the given RPC client.
+// The RPC client can use any transport (HTTP or WebSocket)
+func NewClientFromRPC(client rpcClient.Client) *Client {
 	return &Client{
 		client: client,
-	}, nil
+	}
 }
 
-func (c *Client) GetAccount(address crypto.Address) (std.Account, error) {
+func (c *Client) GetAccount(ctx context.Context, address crypto.Address) (std.Account, error) {
 	path := fmt.Sprintf("auth/accounts/%s", address.String())
 
-	queryResponse, err := c.client.ABCIQuery(context.Background(), path, []byte{})
+	queryResponse, err := c.client.ABCIQuery(ctx, path, []byte{})
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute ABCI query, %w", err)
 	}
@@ -45,24 +57,71 @@ func (c *Client) GetAccount(address crypto.Address) (std.Account, error) {
 	return &queryData.BaseAccount, nil
 }
 
-func (c *Client) SendTransactionSync(tx *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+func (c *Client) GetGasPrice(ctx context.Context) (std.GasPrice, error) {
+	queryResponse, err := c.client.ABCIQuery(ctx, gasPricePath, []byte{})
+	if err != nil {
+		return std.GasPrice{}, fmt.Errorf("unable to execute ABCI query, %w", err)
+	}
+
+	if queryResponse.Response.IsErr() {
+		return std.GasPrice{}, fmt.Errorf("unable to fetch gas price, %w", queryResponse.Response.Error)
+	}
+
+	var gasPrice std.GasPrice
+
+	if err := amino.UnmarshalJSON(queryResponse.Response.Data, &gasPrice); err != nil {
+		return std.GasPrice{}, err
+	}
+
+	return gasPrice, nil
+}
+
+func (c *Client) SimulateTransaction(ctx context.Context, tx *std.Tx) (*abci.ResponseDeliverTx, error) {
 	aminoTx, err := amino.Marshal(tx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to marshal transaction, %w", err)
 	}
 
-	return c.client.BroadcastTxSync(context.Background(), aminoTx)
+	queryResponse, err := c.client.ABCIQuery(ctx, simulatePath, aminoTx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute ABCI query, %w", err)
+	}
+
+	if queryResponse.Response.IsErr() {
+		return nil, fmt.Errorf("unable to simulate transaction, %w", queryResponse.Response.Error)
+	}
+
+	var deliverTx abci.ResponseDeliverTx
+
+	if err := amino.Unmarshal(queryResponse.Response.Value, &deliverTx); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal simulation result, %w", err)
+	}
+
+	return &deliverTx, nil
+}
+
+func (c *Client) SendTransactionSync(ctx context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+	aminoTx, err := amino.Marshal(tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal transaction, %w", err)
+	}
+
+	return c.client.BroadcastTxSync(ctx, aminoTx)
 }
 
-func (c *Client) SendTransactionCommit(tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+func (c *Client) SendTransactionCommit(ctx context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
 	aminoTx, err := amino.Marshal(tx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to marshal transaction, %w", err)
 	}
 
-	return c.client.BroadcastTxCommit(context.Background(), aminoTx)
+	return c.client.BroadcastTxCommit(ctx, aminoTx)
+}
+
+func (c *Client) GetTransaction(ctx context.Context, hash []byte) (*coreTypes.ResultTx, error) {
+	return c.client.Tx(ctx, hash)
 }
 
-func (c *Client) Status() (*coreTypes.ResultStatus, error) {
-	return c.client.Status(context.Background(), nil)
+func (c *Client) Status(ctx context.Context) (*coreTypes.ResultStatus, error) {
+	return c.client.Status(ctx, nil)
 }
@@ -0,0 +1,21 @@
+package ws
+
+import "time"
+
+type Option func(c *Client)
+
+// WithInclusionTimeout sets the max time a committed send
+// waits for the transaction to be included in a block
+func WithInclusionTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.inclusionTimeout = timeout
+	}
+}
+
+// WithPollInterval sets the interval the node is queried
+// for the transaction, while waiting for its inclusion
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.pollInterval = interval
+	}
+}
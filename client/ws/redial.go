@@ -0,0 +1,127 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	wsClient "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/client/ws"
+	rpcTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gorilla/websocket"
+)
+
+var errClientClosed = errors.New("client is closed")
+
+// redialCaller is a WebSocket JSON-RPC caller that redials the node
+// when the node closes the connection (ex. on a node restart).
+// The underlying WS client shuts down for good once the connection
+// is closed by the node, so it's replaced with a freshly dialed one
+type redialCaller struct {
+	remote string
+	client *wsClient.Client // the client on the latest connection
+
+	closed bool
+	mux    sync.Mutex
+}
+
+// newRedialCaller creates a new redialing caller, connected to the remote
+func newRedialCaller(remote string) (*redialCaller, error) {
+	client, err := wsClient.NewClient(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redialCaller{
+		remote: remote,
+		client: client,
+	}, nil
+}
+
+func (r *redialCaller) SendRequest(
+	ctx context.Context,
+	request rpcTypes.RPCRequest,
+) (*rpcTypes.RPCResponse, error) {
+	return withRedial(r, func(client *wsClient.Client) (*rpcTypes.RPCResponse, error) {
+		return client.SendRequest(ctx, request)
+	})
+}
+
+func (r *redialCaller) SendBatch(
+	ctx context.Context,
+	requests rpcTypes.RPCRequests,
+) (rpcTypes.RPCResponses, error) {
+	return withRedial(r, func(client *wsClient.Client) (rpcTypes.RPCResponses, error) {
+		return client.SendBatch(ctx, requests)
+	})
+}
+
+// Close closes the active connection, and stops any further redials
+func (r *redialCaller) Close() error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.closed = true
+
+	return r.client.Close()
+}
+
+// current returns the client on the latest connection
+func (r *redialCaller) current() *wsClient.Client {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	return r.client
+}
+
+// redial replaces the client on the connection closed by the node,
+// unless it was already replaced by a concurrent call
+func (r *redialCaller) redial(stale *wsClient.Client) (*wsClient.Client, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.closed {
+		return nil, errClientClosed
+	}
+
+	if r.client != stale {
+		return r.client, nil
+	}
+
+	client, err := wsClient.NewClient(r.remote)
+	if err != nil {
+		return nil, fmt.Errorf("unable to redial node, %w", err)
+	}
+
+	r.client = client
+
+	return client, nil
+}
+
+// withRedial executes the call on the latest connection. If the node
+// closed the connection, the call is retried once on a new connection.
+// Retried transaction broadcasts are safe, since the node rejects
+// the transaction if the first broadcast went through
+func withRedial[T any](r *redialCaller, callFn func(*wsClient.Client) (T, error)) (T, error) {
+	client := r.current()
+
+	result, err := callFn(client)
+	if !isConnectionClosed(err) {
+		return result, err
+	}
+
+	client, redialErr := r.redial(client)
+	if redialErr != nil {
+		return result, fmt.Errorf("%w, %w", err, redialErr)
+	}
+
+	return callFn(client)
+}
+
+// isConnectionClosed checks if the error is the WS client
+// shutting down, because the node closed the connection
+func isConnectionClosed(err error) bool {
+	var closeErr *websocket.CloseError
+
+	return errors.As(err, &closeErr)
+}
@@ -0,0 +1,128 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/faucet/client/http"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	rpcClient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const (
+	DefaultInclusionTimeout = 30 * time.Second
+	DefaultPollInterval     = 500 * time.Millisecond
+)
+
+var errInclusionTimeout = errors.New("transaction not included in time")
+
+// Client is the TM2 WebSocket client. All calls share a single
+// WebSocket connection to the node (redialed if the node closes it),
+// and committed sends are broadcast in sync mode, and then wait for
+// the transaction to be included in a block, instead of holding
+// the node's commit broadcast open. TM2 nodes don't expose event subscriptions
+// over RPC, so the inclusion is confirmed by querying the node for
+// the transaction over the open connection
+type Client struct {
+	*http.Client
+
+	rpc *rpcClient.RPCClient
+
+	inclusionTimeout time.Duration // the max wait for the tx inclusion
+	pollInterval     time.Duration // the tx inclusion poll interval
+}
+
+// NewClient creates a new TM2 WebSocket client,
+// connected to the given remote (ws://, or wss://)
+func NewClient(remote string, opts ...Option) (*Client, error) {
+	caller, err := newRedialCaller(remote)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create WS client, %w", err)
+	}
+
+	rpc := rpcClient.NewRPCClient(caller)
+
+	c := &Client{
+		Client:           http.NewClientFromRPC(rpc),
+		rpc:              rpc,
+		inclusionTimeout: DefaultInclusionTimeout,
+		pollInterval:     DefaultPollInterval,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// SendTransactionCommit sends the specified transaction to the network
+// in sync mode, so initial validation failures are returned right away.
+// It then waits for the transaction to be included in a block,
+// up to the inclusion timeout
+func (c *Client) SendTransactionCommit(ctx context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+	response, err := c.SendTransactionSync(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &coreTypes.ResultBroadcastTxCommit{
+		CheckTx: abci.ResponseCheckTx{
+			ResponseBase: abci.ResponseBase{
+				Error: response.Error,
+				Data:  response.Data,
+				Log:   response.Log,
+			},
+		},
+		Hash: response.Hash,
+	}
+
+	// The transaction was not added to the mempool
+	if response.Error != nil {
+		return result, nil
+	}
+
+	txResult, err := c.waitForTransaction(ctx, response.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to confirm transaction inclusion, %w", err)
+	}
+
+	result.DeliverTx = txResult.TxResult
+	result.Height = txResult.Height
+
+	return result, nil
+}
+
+// waitForTransaction polls the node for the transaction with the given hash,
+// until it's included in a block, or the inclusion timeout passes
+func (c *Client) waitForTransaction(ctx context.Context, hash []byte) (*coreTypes.ResultTx, error) {
+	ctx, cancelFn := context.WithTimeout(ctx, c.inclusionTimeout)
+	defer cancelFn()
+
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Transactions that are not yet included
+		// are reported as an error by the node
+		txResult, err := c.GetTransaction(ctx, hash)
+		if err == nil {
+			return txResult, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w, %w", errInclusionTimeout, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close closes the WebSocket connection to the node
+func (c *Client) Close() error {
+	return c.rpc.Close()
+}
@@ -0,0 +1,270 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpcTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// methodHandler handles a single node JSON-RPC method,
+// returning the method result, or nil for an error response
+type methodHandler func() any
+
+// dropConnection is the method result that makes
+// the test node close the connection, unanswered
+type dropConnection struct{}
+
+// newTestNode creates a WebSocket JSON-RPC node stand-in,
+// serving the given method handlers
+func newTestNode(t *testing.T, handlers map[string]methodHandler) string {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			var request rpcTypes.RPCRequest
+			if err := json.Unmarshal(data, &request); err != nil {
+				return
+			}
+
+			response := rpcTypes.NewRPCErrorResponse(request.ID, 0, "not found", "")
+
+			if handler, ok := handlers[request.Method]; ok {
+				result := handler()
+
+				if _, drop := result.(dropConnection); drop {
+					_ = conn.WriteControl( //nolint:errcheck // the connection is dropped either way
+						websocket.CloseMessage,
+						websocket.FormatCloseMessage(websocket.CloseGoingAway, "node restarting"),
+						time.Now().Add(time.Second),
+					)
+
+					return
+				}
+
+				if result != nil {
+					response = rpcTypes.NewRPCSuccessResponse(request.ID, result)
+				}
+			}
+
+			if err := conn.WriteJSON(response); err != nil {
+				return
+			}
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+// newTestClient creates a new WS client for the test node
+func newTestClient(t *testing.T, remote string, opts ...Option) *Client {
+	t.Helper()
+
+	c, err := NewClient(remote, opts...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = c.Close() //nolint:errcheck // test cleanup
+	})
+
+	return c
+}
+
+func TestClient_SendTransactionCommit(t *testing.T) {
+	t.Parallel()
+
+	hash := []byte("tx hash")
+
+	t.Run("initial validation failed", func(t *testing.T) {
+		t.Parallel()
+
+		var txQueries atomic.Int64
+
+		remote := newTestNode(t, map[string]methodHandler{
+			"broadcast_tx_sync": func() any {
+				return &coreTypes.ResultBroadcastTx{
+					Error: std.InsufficientFundsError{},
+					Hash:  hash,
+				}
+			},
+			"tx": func() any {
+				txQueries.Add(1)
+
+				return nil
+			},
+		})
+
+		c := newTestClient(t, remote)
+
+		result, err := c.SendTransactionCommit(context.Background(), &std.Tx{})
+		require.NoError(t, err)
+
+		// Make sure the check error is returned right away
+		assert.True(t, result.CheckTx.IsErr())
+		assert.Zero(t, txQueries.Load())
+	})
+
+	t.Run("transaction included", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			txQueries atomic.Int64
+
+			height    = int64(10)
+			deliverTx = abci.ResponseDeliverTx{
+				GasWanted: 100,
+				GasUsed:   50,
+			}
+		)
+
+		remote := newTestNode(t, map[string]methodHandler{
+			"broadcast_tx_sync": func() any {
+				return &coreTypes.ResultBroadcastTx{
+					Hash: hash,
+				}
+			},
+			"tx": func() any {
+				// The transaction is included on the third query
+				if txQueries.Add(1) < 3 {
+					return nil
+				}
+
+				return &coreTypes.ResultTx{
+					Hash:     hash,
+					Height:   height,
+					TxResult: deliverTx,
+				}
+			},
+		})
+
+		c := newTestClient(t, remote, WithPollInterval(5*time.Millisecond))
+
+		result, err := c.SendTransactionCommit(context.Background(), &std.Tx{})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(3), txQueries.Load())
+		assert.False(t, result.CheckTx.IsErr())
+		assert.Equal(t, hash, result.Hash)
+		assert.Equal(t, height, result.Height)
+		assert.Equal(t, deliverTx.GasUsed, result.DeliverTx.GasUsed)
+		assert.Equal(t, deliverTx.GasWanted, result.DeliverTx.GasWanted)
+	})
+
+	t.Run("inclusion timed out", func(t *testing.T) {
+		t.Parallel()
+
+		remote := newTestNode(t, map[string]methodHandler{
+			"broadcast_tx_sync": func() any {
+				return &coreTypes.ResultBroadcastTx{
+					Hash: hash,
+				}
+			},
+		})
+
+		c := newTestClient(
+			t,
+			remote,
+			WithInclusionTimeout(50*time.Millisecond),
+			WithPollInterval(5*time.Millisecond),
+		)
+
+		_, err := c.SendTransactionCommit(context.Background(), &std.Tx{})
+		assert.ErrorIs(t, err, errInclusionTimeout)
+	})
+}
+
+func TestClient_Redial(t *testing.T) {
+	t.Parallel()
+
+	hash := []byte("tx hash")
+
+	t.Run("connection closed by the node", func(t *testing.T) {
+		t.Parallel()
+
+		var broadcasts atomic.Int64
+
+		remote := newTestNode(t, map[string]methodHandler{
+			"broadcast_tx_sync": func() any {
+				// The node goes away on the first broadcast
+				if broadcasts.Add(1) == 1 {
+					return dropConnection{}
+				}
+
+				return &coreTypes.ResultBroadcastTx{
+					Error: std.InsufficientFundsError{},
+					Hash:  hash,
+				}
+			},
+		})
+
+		c := newTestClient(t, remote)
+
+		result, err := c.SendTransactionCommit(context.Background(), &std.Tx{})
+		require.NoError(t, err)
+
+		// Make sure the broadcast went through on a new connection
+		assert.Equal(t, int64(2), broadcasts.Load())
+		assert.Equal(t, hash, result.Hash)
+
+		// Make sure the new connection keeps serving calls
+		_, err = c.SendTransactionCommit(context.Background(), &std.Tx{})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(3), broadcasts.Load())
+	})
+
+	t.Run("client closed", func(t *testing.T) {
+		t.Parallel()
+
+		var broadcasts atomic.Int64
+
+		remote := newTestNode(t, map[string]methodHandler{
+			"broadcast_tx_sync": func() any {
+				broadcasts.Add(1)
+
+				return &coreTypes.ResultBroadcastTx{
+					Hash: hash,
+				}
+			},
+		})
+
+		c, err := NewClient(remote)
+		require.NoError(t, err)
+
+		require.NoError(t, c.Close())
+
+		// Make sure the closed client doesn't redial
+		_, err = c.SendTransactionCommit(context.Background(), &std.Tx{})
+		require.Error(t, err)
+
+		assert.Zero(t, broadcasts.Load())
+	})
+}
@@ -5,15 +5,30 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"os/signal"
 	"regexp"
 	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gnolang/faucet"
+	"github.com/gnolang/faucet/client"
+	"github.com/gnolang/faucet/client/failover"
 	tm2Client "github.com/gnolang/faucet/client/http"
+	"github.com/gnolang/faucet/client/ws"
 	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/estimate"
+	"github.com/gnolang/faucet/estimate/dynamic"
+	"github.com/gnolang/faucet/estimate/simulate"
 	"github.com/gnolang/faucet/estimate/static"
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/faucet/keyring/file"
+	"github.com/gnolang/faucet/keyring/memory"
+	"github.com/gnolang/faucet/keyring/remote"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/pelletier/go-toml"
 	"github.com/peterbourgon/ff/v3"
@@ -29,10 +44,21 @@ const (
 const (
 	defaultGasFee    = "1000000ugnot"
 	defaultGasWanted = "100000"
+	defaultGasPrice  = "10ugnot/1gas"
 	defaultRemote    = "http://127.0.0.1:26657"
 )
 
-var remoteRegex = regexp.MustCompile(`^https?://[a-z\d.-]+(:\d+)?(?:/[a-z\d]+)*$`)
+const (
+	gasEstimatorStatic   = "static"
+	gasEstimatorSimulate = "simulate"
+)
+
+const (
+	feeEstimatorStatic  = "static"
+	feeEstimatorDynamic = "dynamic"
+)
+
+var remoteRegex = regexp.MustCompile(`^(https?|wss?)://[a-z\d.-]+(:\d+)?(?:/[a-z\d]+)*$`)
 
 // faucetCfg wraps the faucet
 // root command configuration
@@ -41,8 +67,28 @@ type faucetCfg struct {
 
 	faucetConfigPath string
 	remote           string
+	inclusionTimeout time.Duration
 	gasFee           string
 	gasWanted        string
+	gasPerMessage    int64
+	gasEstimator     string
+	gasMultiplier    float64
+	gasFloor         int64
+	gasCeiling       int64
+	feeEstimator     string
+	gasPrice         string
+	gasPriceInterval time.Duration
+	gasPriceMargin   int64
+
+	healthCheckInterval time.Duration
+
+	keyringDir            string
+	keyringKeys           string
+	keyringPassphrase     string
+	keyringPassphraseFile string
+	remoteSigner          string
+	keysFile              string
+	sweepOnRotate         bool
 }
 
 // newRootCmd creates the root faucet command
@@ -104,6 +150,27 @@ func (c *faucetCfg) registerRootFlags(fs *flag.FlagSet) {
 		"the mnemonic for faucet keys",
 	)
 
+	fs.StringVar(
+		&c.config.MnemonicPassphrase,
+		"mnemonic-passphrase",
+		"",
+		"the BIP39 passphrase for the mnemonic, if any",
+	)
+
+	fs.Uint64Var(
+		&c.config.HDAccount,
+		"hd-account",
+		0,
+		"the BIP44 account the faucet keys are derived at",
+	)
+
+	fs.Uint64Var(
+		&c.config.HDStartIndex,
+		"hd-start-index",
+		0,
+		"the BIP44 address index of the first faucet key",
+	)
+
 	fs.Uint64Var(
 		&c.config.NumAccounts,
 		"num-accounts",
@@ -115,7 +182,133 @@ func (c *faucetCfg) registerRootFlags(fs *flag.FlagSet) {
 		&c.config.MaxSendAmount,
 		"send-amount",
 		config.DefaultMaxSendAmount,
-		"the static max send amount per drip (native currency)",
+		"the static max send amount per drip, per denomination. Format: <AMOUNT><DENOM>[,<AMOUNT><DENOM>...]",
+	)
+
+	fs.StringVar(
+		&c.config.MinSendAmount,
+		"min-send-amount",
+		"",
+		"the min send amount per drip, per denomination (optional). Format: <AMOUNT><DENOM>[,<AMOUNT><DENOM>...]",
+	)
+
+	fs.StringVar(
+		&c.config.AccountSelection,
+		"account-selection",
+		config.DefaultAccountSelection,
+		"the faucet account selection strategy (round-robin, least-recently-used, highest-balance, random)",
+	)
+
+	fs.StringVar(
+		&c.config.BroadcastMode,
+		"broadcast-mode",
+		config.DefaultBroadcastMode,
+		"the transaction broadcast mode (commit, sync)",
+	)
+
+	fs.StringVar(
+		&c.config.MemoTemplate,
+		"memo-template",
+		"",
+		"the drip transaction memo template (optional). Fields: {{.RequestID}}, {{.Beneficiary}}, {{.Route}}, {{.Tag}}",
+	)
+
+	fs.StringVar(
+		&c.config.MemoTag,
+		"memo-tag",
+		"",
+		"the custom tag available to the memo template",
+	)
+
+	fs.BoolVar(
+		&c.config.SimulateTransactions,
+		"simulate-transactions",
+		false,
+		"flag indicating if drip transactions are simulated before being broadcast",
+	)
+
+	fs.Float64Var(
+		&c.config.FeeBumpFactor,
+		"fee-bump-factor",
+		0,
+		"the factor the gas fee is bumped by, when a drip is rejected for an insufficient fee (0 disables bumping)",
+	)
+
+	fs.StringVar(
+		&c.config.MaxGasFee,
+		"max-gas-fee",
+		"",
+		"the hard cap for bumped gas fees. Format: <AMOUNT><DENOM>",
+	)
+
+	fs.DurationVar(
+		&c.config.FeeBumpExpiry,
+		"fee-bump-expiry",
+		config.DefaultFeeBumpExpiry,
+		"the period an accepted bumped gas fee is used for subsequent drips",
+	)
+
+	fs.DurationVar(
+		&c.config.BatchWindow,
+		"batch-window",
+		0,
+		"the period drips are collected for, before being sent as a single transaction (0 disables batching)",
+	)
+
+	fs.Uint64Var(
+		&c.config.MaxBatchSize,
+		"max-batch-size",
+		config.DefaultMaxBatchSize,
+		"the max number of drips in a single batch transaction",
+	)
+
+	fs.Uint64Var(
+		&c.config.QueueWorkers,
+		"queue-workers",
+		0,
+		"the number of workers executing queued drips (0 uses a worker per faucet account)",
+	)
+
+	fs.Uint64Var(
+		&c.config.MaxQueueDepth,
+		"max-queue-depth",
+		config.DefaultMaxQueueDepth,
+		"the max number of drips waiting in the queue",
+	)
+
+	fs.DurationVar(
+		&c.config.AccountRefreshInterval,
+		"account-refresh-interval",
+		0,
+		"the period the cached faucet accounts are refreshed from the chain (0 disables caching)",
+	)
+
+	fs.DurationVar(
+		&c.config.RebalanceInterval,
+		"rebalance-interval",
+		0,
+		"the period the faucet accounts are checked for top-ups from the treasury (0 disables rebalancing)",
+	)
+
+	fs.Uint64Var(
+		&c.config.TreasuryAccount,
+		"treasury-account",
+		0,
+		"the index of the treasury faucet account, which tops up the other faucet accounts",
+	)
+
+	fs.StringVar(
+		&c.config.LowWaterMark,
+		"low-water-mark",
+		config.DefaultLowWaterMark,
+		"the balance under which a faucet account is topped up. Format: <AMOUNT><DENOM>[,<AMOUNT><DENOM>...]",
+	)
+
+	fs.StringVar(
+		&c.config.TopUpAmount,
+		"top-up-amount",
+		config.DefaultTopUpAmount,
+		"the amount a faucet account is topped up with. Format: <AMOUNT><DENOM>[,<AMOUNT><DENOM>...]",
 	)
 
 	fs.StringVar(
@@ -132,6 +325,69 @@ func (c *faucetCfg) registerRootFlags(fs *flag.FlagSet) {
 		"the static gas wanted for the transaction. Format: <AMOUNT>ugnot",
 	)
 
+	fs.Int64Var(
+		&c.gasPerMessage,
+		"gas-wanted-per-message",
+		0,
+		"the additional static gas wanted for each transaction message past the first",
+	)
+
+	fs.StringVar(
+		&c.gasEstimator,
+		"gas-estimator",
+		gasEstimatorStatic,
+		"the gas wanted estimator (static, simulate). The simulate estimator falls back to the static gas wanted",
+	)
+
+	fs.Float64Var(
+		&c.gasMultiplier,
+		"gas-multiplier",
+		simulate.DefaultMultiplier,
+		"the safety multiplier for the simulated gas used, with the simulate estimator",
+	)
+
+	fs.Int64Var(
+		&c.gasFloor,
+		"gas-floor",
+		simulate.DefaultFloor,
+		"the min gas wanted, with the simulate estimator",
+	)
+
+	fs.Int64Var(
+		&c.gasCeiling,
+		"gas-ceiling",
+		simulate.DefaultCeiling,
+		"the max gas wanted, with the simulate estimator",
+	)
+
+	fs.StringVar(
+		&c.feeEstimator,
+		"fee-estimator",
+		feeEstimatorStatic,
+		"the gas fee estimator (static, dynamic). The dynamic estimator prices the gas wanted at the chain gas price",
+	)
+
+	fs.StringVar(
+		&c.gasPrice,
+		"gas-price",
+		defaultGasPrice,
+		"the fallback gas price, with the dynamic fee estimator. Format: <AMOUNT><DENOM>/<GAS>gas",
+	)
+
+	fs.DurationVar(
+		&c.gasPriceInterval,
+		"gas-price-interval",
+		dynamic.DefaultInterval,
+		"the period the chain gas price is fetched, with the dynamic fee estimator",
+	)
+
+	fs.Int64Var(
+		&c.gasPriceMargin,
+		"gas-price-margin",
+		dynamic.DefaultPriceMargin,
+		"the safety margin over the chain gas price (in percent), with the dynamic fee estimator",
+	)
+
 	fs.StringVar(
 		&c.faucetConfigPath,
 		"faucet-config",
@@ -143,12 +399,81 @@ func (c *faucetCfg) registerRootFlags(fs *flag.FlagSet) {
 		&c.remote,
 		"remote",
 		defaultRemote,
-		"the JSON-RPC URL of the Gno chain",
+		"the JSON-RPC URL of the Gno chain. WebSocket URLs (ws://, wss://) broadcast drips in sync mode, "+
+			"and then wait for their inclusion. Multiple comma-separated URLs fail over between the nodes",
+	)
+
+	fs.DurationVar(
+		&c.healthCheckInterval,
+		"health-check-interval",
+		failover.DefaultInterval,
+		"the node health check interval, for multiple remotes",
+	)
+
+	fs.DurationVar(
+		&c.inclusionTimeout,
+		"inclusion-timeout",
+		ws.DefaultInclusionTimeout,
+		"the max time a drip waits for its transaction inclusion, for WebSocket remotes",
+	)
+
+	fs.StringVar(
+		&c.keyringDir,
+		"keyring-dir",
+		"",
+		"the gnokey home directory of the encrypted keybase holding the faucet keys. "+
+			"If set, the faucet keys are loaded from the keybase instead of derived from the mnemonic",
+	)
+
+	fs.StringVar(
+		&c.keyringKeys,
+		"keyring-keys",
+		"",
+		"the names (or addresses) of the keybase keys used by the faucet, comma separated. "+
+			"All local keys are used if not set",
+	)
+
+	fs.StringVar(
+		&c.keysFile,
+		"keys-file",
+		"",
+		"the path to the file listing the faucet private keys, hex-encoded or armored (one per line). "+
+			"If set, the faucet keys are loaded from the file instead of derived from the mnemonic",
+	)
+
+	fs.StringVar(
+		&c.keyringPassphrase,
+		"keyring-passphrase",
+		"",
+		"the keybase (or encrypted keys file) passphrase. "+
+			"Prefer setting it through the "+envPrefix+"_KEYRING_PASSPHRASE env variable",
+	)
+
+	fs.StringVar(
+		&c.keyringPassphraseFile,
+		"keyring-passphrase-file",
+		"",
+		"the path to the file containing the keybase (or encrypted keys file) passphrase",
+	)
+
+	fs.BoolVar(
+		&c.sweepOnRotate,
+		"sweep-on-rotate",
+		false,
+		"the flag indicating if the old faucet account balances are swept to the new accounts, "+
+			"when the faucet keys are reloaded (SIGHUP)",
+	)
+
+	fs.StringVar(
+		&c.remoteSigner,
+		"remote-signer",
+		"",
+		"the URL of the remote signer HTTP API. If set, the faucet keys never leave the remote signer",
 	)
 }
 
 // exec executes the faucet root command
-func (c *faucetCfg) exec(_ context.Context, _ []string) error {
+func (c *faucetCfg) exec(ctx context.Context, _ []string) error {
 	// Read the faucet configuration, if any
 	if c.faucetConfigPath != "" {
 		faucetConfig, err := readFaucetConfig(c.faucetConfigPath)
@@ -160,9 +485,8 @@ func (c *faucetCfg) exec(_ context.Context, _ []string) error {
 	}
 
 	// Parse static gas values.
-	// It is worth noting that this is temporary,
-	// and will be removed once gas estimation is enabled
-	// on Gno.land
+	// With simulation-based estimation, the static
+	// gas wanted is the fallback estimate
 	gasFee, err := std.ParseCoin(c.gasFee)
 	if err != nil {
 		return fmt.Errorf("invalid gas fee, %w", err)
@@ -173,42 +497,318 @@ func (c *faucetCfg) exec(_ context.Context, _ []string) error {
 		return fmt.Errorf("invalid gas wanted, %w", err)
 	}
 
-	// Validate the remote address
-	if !remoteRegex.MatchString(c.remote) {
-		return errors.New("invalid remote address")
+	if c.gasPerMessage < 0 {
+		return errors.New("invalid gas wanted per message")
+	}
+
+	// Validate the remote addresses
+	remotes := strings.Split(c.remote, ",")
+
+	for _, remote := range remotes {
+		if !remoteRegex.MatchString(remote) {
+			return fmt.Errorf("invalid remote address, %s", remote)
+		}
 	}
 
 	// Create a new logger
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	// Create the tm2 client
-	client, err := tm2Client.NewClient(c.remote)
-	if err != nil {
-		return fmt.Errorf("unable to create client, %w", err)
+	// Create the tm2 client for each remote
+	nodeClients := make([]client.Client, 0, len(remotes))
+
+	// Close the node connections (WebSocket), once the faucet exits
+	defer func() {
+		for _, nodeClient := range nodeClients {
+			if closer, ok := nodeClient.(io.Closer); ok {
+				_ = closer.Close()
+			}
+		}
+	}()
+
+	for _, remote := range remotes {
+		nodeClient, err := c.newClient(remote)
+		if err != nil {
+			return fmt.Errorf("unable to create client, %w", err)
+		}
+
+		nodeClients = append(nodeClients, nodeClient)
 	}
 
-	// Create a new faucet with
-	// static gas estimation
-	f, err := faucet.NewFaucet(
-		static.New(gasFee, gasWanted),
-		client,
+	client := nodeClients[0]
+
+	// Fail over between the remotes, if multiple
+	var failoverClient *failover.Client
+
+	if len(nodeClients) > 1 {
+		if c.healthCheckInterval <= 0 {
+			return errors.New("invalid health check interval")
+		}
+
+		failoverClient, err = failover.New(
+			nodeClients,
+			failover.WithInterval(c.healthCheckInterval),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to create failover client, %w", err)
+		}
+
+		client = failoverClient
+	}
+
+	// Create the gas estimator
+	staticEstimator := static.New(
+		gasFee,
+		gasWanted,
+		static.WithGasPerMessage(c.gasPerMessage),
+	)
+
+	var (
+		estimator        estimate.Estimator = staticEstimator
+		dynamicEstimator *dynamic.Estimator
+	)
+
+	switch c.feeEstimator {
+	case feeEstimatorStatic:
+		// Static gas fee estimation is the default
+	case feeEstimatorDynamic:
+		gasPrice, err := std.ParseGasPrice(c.gasPrice)
+		if err != nil {
+			return fmt.Errorf("invalid gas price, %w", err)
+		}
+
+		if c.gasPriceInterval <= 0 {
+			return errors.New("invalid gas price interval")
+		}
+
+		if c.gasPriceMargin < 0 {
+			return errors.New("invalid gas price margin")
+		}
+
+		dynamicEstimator = dynamic.New(
+			client,
+			gasPrice,
+			gasWanted,
+			dynamic.WithInterval(c.gasPriceInterval),
+			dynamic.WithPriceMargin(c.gasPriceMargin),
+			dynamic.WithGasPerMessage(c.gasPerMessage),
+		)
+
+		estimator = dynamicEstimator
+	default:
+		return fmt.Errorf("invalid fee estimator, %s", c.feeEstimator)
+	}
+
+	switch c.gasEstimator {
+	case gasEstimatorStatic:
+		// Static gas estimation is the default
+	case gasEstimatorSimulate:
+		if c.gasMultiplier < 1 {
+			return errors.New("invalid gas multiplier, should be at least 1")
+		}
+
+		if c.gasFloor < 0 || c.gasCeiling < c.gasFloor {
+			return errors.New("invalid gas floor and ceiling")
+		}
+
+		estimator = simulate.New(
+			client,
+			estimator,
+			simulate.WithMultiplier(c.gasMultiplier),
+			simulate.WithFloor(c.gasFloor),
+			simulate.WithCeiling(c.gasCeiling),
+		)
+	default:
+		return fmt.Errorf("invalid gas estimator, %s", c.gasEstimator)
+	}
+
+	opts := []faucet.Option{
 		faucet.WithLogger(logger),
 		faucet.WithConfig(c.config),
+	}
+
+	// Load the encrypted, file or remote keyring, if any.
+	// Otherwise, the faucet keys are derived from the mnemonic
+	if c.keyringDir != "" || c.keysFile != "" || c.remoteSigner != "" {
+		kr, err := c.loadKeyring(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to load keyring, %w", err)
+		}
+
+		opts = append(opts, faucet.WithKeyring(kr))
+	}
+
+	// Create a new faucet
+	f, err := faucet.NewFaucet(
+		estimator,
+		client,
+		opts...,
 	)
 	if err != nil {
 		return fmt.Errorf("unable to create faucet, %w", err)
 	}
 
 	// Create a new waiter
-	w := newWaiter()
+	w := newWaiter(ctx)
+
+	// Add the gas price refresh service, if any
+	if dynamicEstimator != nil {
+		w.add(dynamicEstimator.Run)
+	}
 
-	// Add the faucet service
-	w.add(f.Serve)
+	// Add the node health check service, if any
+	if failoverClient != nil {
+		w.add(failoverClient.Run)
+	}
+
+	// Add the faucet service, and the
+	// faucet key reloading (SIGHUP)
+	w.add(
+		f.Serve,
+		c.reloadKeyring(f, logger),
+	)
 
 	// Wait for the faucet to exit
 	return w.wait()
 }
 
+// newClient creates the tm2 client for the remote. WebSocket remotes
+// confirm the drip transaction inclusion without holding a commit
+// broadcast open on the node
+func (c *faucetCfg) newClient(remote string) (client.Client, error) {
+	if !strings.HasPrefix(remote, "ws") {
+		return tm2Client.NewClient(remote)
+	}
+
+	if c.inclusionTimeout <= 0 {
+		return nil, errors.New("invalid inclusion timeout")
+	}
+
+	return ws.NewClient(remote, ws.WithInclusionTimeout(c.inclusionTimeout))
+}
+
+// loadKeyring loads the faucet keyring from the encrypted keybase, the keys
+// file or the remote signer. The keybase and the keys file are unlocked
+// with the passphrase from the file or the flag (env)
+func (c *faucetCfg) loadKeyring(ctx context.Context) (keyring.Keyring, error) {
+	sources := 0
+
+	for _, source := range []string{c.keyringDir, c.keysFile, c.remoteSigner} {
+		if source != "" {
+			sources++
+		}
+	}
+
+	if sources > 1 {
+		return nil, errors.New("keyring dir, keys file and remote signer are mutually exclusive")
+	}
+
+	if c.remoteSigner != "" {
+		return remote.New(ctx, c.remoteSigner)
+	}
+
+	passphrase, err := c.readPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.keysFile != "" {
+		keys, err := memory.ReadKeys(c.keysFile, passphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		return memory.NewFromKeys(keys...), nil
+	}
+
+	var names []string
+
+	if c.keyringKeys != "" {
+		names = strings.Split(c.keyringKeys, ",")
+	}
+
+	return file.New(c.keyringDir, passphrase, names...)
+}
+
+// reloadKeyring rotates the faucet keyring on SIGHUP, with the keys reloaded
+// from their source (the keybase, the keys file, the remote signer, or the
+// mnemonic from the faucet config file), until the context is done [BLOCKING]
+func (c *faucetCfg) reloadKeyring(f *faucet.Faucet, logger *slog.Logger) waitFunc {
+	return func(ctx context.Context) error {
+		reload := make(chan os.Signal, 1)
+
+		signal.Notify(reload, syscall.SIGHUP)
+		defer signal.Stop(reload)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-reload:
+			}
+
+			logger.Info("reloading faucet keys")
+
+			kr, err := c.reloadKeys(ctx)
+			if err != nil {
+				logger.Error("unable to reload faucet keys", "error", err)
+
+				continue
+			}
+
+			if err := f.RotateKeyring(ctx, kr, c.sweepOnRotate); err != nil {
+				logger.Error("unable to rotate faucet keys", "error", err)
+			}
+		}
+	}
+}
+
+// reloadKeys loads the faucet keys from their source. Mnemonic
+// keys are reloaded from the faucet config file, if any
+func (c *faucetCfg) reloadKeys(ctx context.Context) (keyring.Keyring, error) {
+	if c.keyringDir != "" || c.keysFile != "" || c.remoteSigner != "" {
+		return c.loadKeyring(ctx)
+	}
+
+	cfg := c.config
+
+	if c.faucetConfigPath != "" {
+		faucetConfig, err := readFaucetConfig(c.faucetConfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read faucet config, %w", err)
+		}
+
+		if err := config.ValidateConfig(faucetConfig); err != nil {
+			return nil, fmt.Errorf("invalid faucet config, %w", err)
+		}
+
+		cfg = faucetConfig
+	}
+
+	//nolint:gosec // the HD account and start index are validated beforehand
+	return memory.New(
+		cfg.Mnemonic,
+		cfg.NumAccounts,
+		memory.WithAccount(uint32(cfg.HDAccount)),
+		memory.WithStartIndex(uint32(cfg.HDStartIndex)),
+		memory.WithPassphrase(cfg.MnemonicPassphrase),
+	), nil
+}
+
+// readPassphrase reads the keyring passphrase
+// from the passphrase file or the flag (env)
+func (c *faucetCfg) readPassphrase() (string, error) {
+	if c.keyringPassphraseFile == "" {
+		return c.keyringPassphrase, nil
+	}
+
+	content, err := os.ReadFile(c.keyringPassphraseFile)
+	if err != nil {
+		return "", fmt.Errorf("unable to read passphrase file, %w", err)
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
 // readFaucetConfig reads the faucet configuration
 // from the specified path
 func readFaucetConfig(path string) (*config.Config, error) {
@@ -218,12 +818,13 @@ func readFaucetConfig(path string) (*config.Config, error) {
 		return nil, err
 	}
 
-	// Parse it
-	var faucetConfig config.Config
+	// Parse it, on top of the defaults,
+	// so omitted fields keep their default values
+	faucetConfig := config.DefaultConfig()
 
-	if err := toml.Unmarshal(content, &faucetConfig); err != nil {
+	if err := toml.Unmarshal(content, faucetConfig); err != nil {
 		return nil, err
 	}
 
-	return &faucetConfig, nil
+	return faucetConfig, nil
 }
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeysFile writes a random faucet key to a temporary keys file
+func writeKeysFile(t *testing.T) string {
+	t.Helper()
+
+	var (
+		key      = secp256k1.GenPrivKey()
+		keysFile = filepath.Join(t.TempDir(), "keys.txt")
+	)
+
+	require.NoError(t, os.WriteFile(keysFile, []byte(hex.EncodeToString(key[:])+"\n"), 0o600))
+
+	return keysFile
+}
+
+func TestServe_KeysFile(t *testing.T) {
+	t.Parallel()
+
+	keysFile := writeKeysFile(t)
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+
+	// Serve the faucet without a mnemonic,
+	// since the keys are loaded from the keys file
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- newRootCmd().ParseAndRun(ctx, []string{
+			"--keys-file", keysFile,
+			"--listen-address", "127.0.0.1:0",
+		})
+	}()
+
+	// Make sure the faucet is serving
+	select {
+	case err := <-errCh:
+		t.Fatalf("faucet exited, %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// Make sure the faucet stops with the context
+	cancelFn()
+
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("faucet did not stop")
+	}
+}
+
+func TestServe_WSClientClosed(t *testing.T) {
+	t.Parallel()
+
+	// Set up a WebSocket node, that notes when the faucet disconnects
+	var (
+		upgrader = websocket.Upgrader{}
+		closedCh = make(chan struct{})
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				close(closedCh)
+
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- newRootCmd().ParseAndRun(ctx, []string{
+			"--keys-file", writeKeysFile(t),
+			"--listen-address", "127.0.0.1:0",
+			"--remote", "ws" + strings.TrimPrefix(srv.URL, "http") + "/websocket",
+		})
+	}()
+
+	// Make sure the faucet is serving
+	select {
+	case err := <-errCh:
+		t.Fatalf("faucet exited, %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// Stop the faucet, and make sure the node connection is closed
+	cancelFn()
+
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("faucet did not stop")
+	}
+
+	select {
+	case <-closedCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("node connection not closed")
+	}
+}
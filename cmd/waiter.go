@@ -19,14 +19,15 @@ type waiter struct {
 	waitFns []waitFunc
 }
 
-// newWaiter creates a new waiter instance
-func newWaiter() *waiter {
+// newWaiter creates a new waiter instance,
+// which stops with the given context
+func newWaiter(ctx context.Context) *waiter {
 	w := &waiter{
 		waitFns: []waitFunc{},
 	}
 
 	w.ctx, w.cancel = signal.NotifyContext(
-		context.Background(),
+		ctx,
 		os.Interrupt,
 		syscall.SIGINT,
 		syscall.SIGTERM,
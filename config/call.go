@@ -0,0 +1,20 @@
+package config
+
+// RealmCall defines the realm call configuration,
+// for faucets that drip through a realm function
+type RealmCall struct {
+	// The path of the called realm (for example gno.land/r/demo/foo20)
+	PkgPath string `toml:"pkg_path"`
+
+	// The called realm function (for example Transfer)
+	Func string `toml:"func"`
+
+	// The call arguments. Each argument is a template, which can
+	// reference the drip {{.ToAddress}}, {{.FromAddress}} and {{.SendAmount}},
+	// or the drip amount of a single denomination ({{.SendAmount.AmountOf "utest"}})
+	Args []string `toml:"args"`
+
+	// The flag indicating if the drip amount is sent along with the call.
+	// Otherwise, the faucet accounts only pay the transaction fee
+	AttachAmount bool `toml:"attach_amount"`
+}
@@ -4,8 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"text/template"
+	"time"
 
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/bip39"
+	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 const (
@@ -13,23 +17,71 @@ const (
 	DefaultChainID       = "dev"
 	DefaultMaxSendAmount = "1000000ugnot"
 	//nolint:lll // Mnemonic is naturally long
-	DefaultMnemonic    = "source bonus chronic canvas draft south burst lottery vacant surface solve popular case indicate oppose farm nothing bullet exhibit title speed wink action roast"
-	DefaultNumAccounts = uint64(1)
+	DefaultMnemonic      = "source bonus chronic canvas draft south burst lottery vacant surface solve popular case indicate oppose farm nothing bullet exhibit title speed wink action roast"
+	DefaultNumAccounts   = uint64(1)
+	DefaultMaxBatchSize  = uint64(50)
+	DefaultMaxQueueDepth = uint64(100)
+	DefaultLowWaterMark  = "10000000ugnot"
+	DefaultTopUpAmount   = "100000000ugnot"
+	DefaultFeeBumpExpiry = 10 * time.Minute
 )
 
-var (
-	ErrInvalidListenAddress = errors.New("invalid listen address")
-	ErrInvalidChainID       = errors.New("invalid chain ID")
-	ErrInvalidSendAmount    = errors.New("invalid send amount")
-	ErrInvalidMnemonic      = errors.New("invalid mnemonic")
-	ErrInvalidNumAccounts   = errors.New("invalid number of faucet accounts")
+const (
+	AccountSelectionRoundRobin     = "round-robin"
+	AccountSelectionLRU            = "least-recently-used"
+	AccountSelectionHighestBalance = "highest-balance"
+	AccountSelectionRandom         = "random"
+
+	DefaultAccountSelection = AccountSelectionRoundRobin
+)
+
+const (
+	BroadcastModeCommit = "commit"
+	BroadcastModeSync   = "sync"
+
+	DefaultBroadcastMode = BroadcastModeCommit
+)
+
+const (
+	DripMessageSend = "send"
+	DripMessageCall = "call"
+
+	DefaultDripMessage = DripMessageSend
 )
 
 var (
-	listenAddressRegex = regexp.MustCompile(`^\d{1,3}(\.\d{1,3}){3}:\d+$`)
-	amountRegex        = regexp.MustCompile(`^\d+ugnot$`)
+	ErrInvalidListenAddress     = errors.New("invalid listen address")
+	ErrInvalidChainID           = errors.New("invalid chain ID")
+	ErrInvalidSendAmount        = errors.New("invalid send amount")
+	ErrInvalidMinSendAmount     = errors.New("invalid min send amount")
+	ErrInvalidMnemonic          = errors.New("invalid mnemonic")
+	ErrInvalidNumAccounts       = errors.New("invalid number of faucet accounts")
+	ErrInvalidHDAccount         = errors.New("invalid HD account")
+	ErrInvalidHDStartIndex      = errors.New("invalid HD start index")
+	ErrInvalidSelection         = errors.New("invalid account selection strategy")
+	ErrInvalidBroadcastMode     = errors.New("invalid broadcast mode")
+	ErrInvalidBatchWindow       = errors.New("invalid batch window")
+	ErrInvalidMaxBatchSize      = errors.New("invalid max batch size")
+	ErrInvalidMaxQueueDepth     = errors.New("invalid max queue depth")
+	ErrInvalidRefreshInterval   = errors.New("invalid account refresh interval")
+	ErrInvalidRebalanceInterval = errors.New("invalid rebalance interval")
+	ErrInvalidTreasuryAccount   = errors.New("invalid treasury account")
+	ErrInvalidLowWaterMark      = errors.New("invalid low-water mark")
+	ErrInvalidTopUpAmount       = errors.New("invalid top-up amount")
+	ErrInvalidFeeBumpFactor     = errors.New("invalid fee bump factor")
+	ErrInvalidMaxGasFee         = errors.New("invalid max gas fee")
+	ErrInvalidFeeBumpExpiry     = errors.New("invalid fee bump expiry")
+	ErrInvalidMemoTemplate      = errors.New("invalid memo template")
+	ErrInvalidDripMessage       = errors.New("invalid drip message")
+	ErrInvalidRealmCall         = errors.New("invalid realm call")
+	ErrInvalidMultisigAccount   = errors.New("invalid multisig account")
 )
 
+var listenAddressRegex = regexp.MustCompile(`^\d{1,3}(\.\d{1,3}){3}:\d+$`)
+
+// maxHDIndex is the max non-hardened HD derivation index
+const maxHDIndex = uint64(1<<31 - 1)
+
 // Config defines the base-level Faucet configuration
 type Config struct {
 	// The associated CORS config, if any
@@ -45,29 +97,199 @@ type Config struct {
 	// The mnemonic for the faucet
 	Mnemonic string `toml:"mnemonic"`
 
-	// The static max send amount (native currency).
-	// Format should be: <AMOUNT>ugnot
+	// The BIP39 passphrase for the mnemonic (optional)
+	MnemonicPassphrase string `toml:"mnemonic_passphrase"`
+
+	// The BIP44 account the faucet keys are derived at
+	HDAccount uint64 `toml:"hd_account"`
+
+	// The BIP44 address index of the first faucet key.
+	// The faucet keys are derived at consecutive indexes
+	HDStartIndex uint64 `toml:"hd_start_index"`
+
+	// The static max send amount, per denomination.
+	// Drips can request any subset of the denominations.
+	// Format should be: <AMOUNT><DENOM>[,<AMOUNT><DENOM>...]
 	MaxSendAmount string `toml:"send_amount"`
 
-	// The number of faucet accounts,
-	// based on the mnemonic (account 0, index x)
+	// The min send amount, per denomination (optional).
+	// Each denomination needs to be a faucet denomination,
+	// with a min amount that doesn't exceed its max amount.
+	// Format should be: <AMOUNT><DENOM>[,<AMOUNT><DENOM>...]
+	MinSendAmount string `toml:"min_send_amount"`
+
+	// The number of faucet accounts, based on the mnemonic
+	// (hd account, hd start index + x)
 	NumAccounts uint64 `toml:"num_accounts"`
+
+	// The strategy for picking the faucet account that serves a drip.
+	// Possible values: round-robin, least-recently-used, highest-balance, random
+	AccountSelection string `toml:"account_selection"`
+
+	// The transaction broadcast mode.
+	// In commit mode, drips wait for the transaction to be committed.
+	// In sync mode, drips return the transaction hash once it passes
+	// the initial validation (CheckTx), and the status is available through
+	// the drip_status method. Possible values: commit, sync
+	BroadcastMode string `toml:"broadcast_mode"`
+
+	// The drip transaction message type. Send drips transfer the
+	// drip amount (bank.MsgSend), and call drips call the configured
+	// realm function (vm.MsgCall). Possible values: send, call
+	DripMessage string `toml:"drip_message"`
+
+	// The realm call configuration, for call drips
+	RealmCall *RealmCall `toml:"realm_call"`
+
+	// The multisig faucet accounts (optional). The member keys are
+	// taken from the faucet keyring, and only sign for the multisig
+	MultisigAccounts []MultisigAccount `toml:"multisig_accounts"`
+
+	// The template for the drip transaction memo (optional).
+	// The template can reference the drip {{.RequestID}}, {{.Beneficiary}},
+	// {{.Route}} and the custom {{.Tag}}. For batched drips, each field lists
+	// the values of every drip in the transaction, comma separated
+	MemoTemplate string `toml:"memo_template"`
+
+	// The custom tag available to the memo template
+	MemoTag string `toml:"memo_tag"`
+
+	// The flag indicating if drip transactions are simulated on the node
+	// before being broadcast. Drips that would fail are rejected
+	// without being broadcast, at the cost of an extra round trip
+	SimulateTransactions bool `toml:"simulate_transactions"`
+
+	// The factor the gas fee is bumped by, when a drip transaction
+	// is rejected for an insufficient fee. The transaction is re-signed
+	// and rebroadcast with the bumped fee, up to the max gas fee.
+	// Fee bumping is disabled if the factor is 0
+	FeeBumpFactor float64 `toml:"fee_bump_factor"`
+
+	// The hard cap for bumped gas fees.
+	// Format should be: <AMOUNT><DENOM>
+	MaxGasFee string `toml:"max_gas_fee"`
+
+	// The period an accepted bumped gas fee is kept as the min gas fee
+	// for subsequent drips, unless the estimated fee catches up sooner
+	FeeBumpExpiry time.Duration `toml:"fee_bump_expiry"`
+
+	// The period drips are collected for, before they are sent
+	// together as a single multi-message transaction.
+	// Batching is disabled if the window is 0
+	BatchWindow time.Duration `toml:"batch_window"`
+
+	// The max number of drips in a single batch transaction.
+	// Make sure the gas wanted can cover a full batch
+	MaxBatchSize uint64 `toml:"max_batch_size"`
+
+	// The number of workers executing queued drips.
+	// If 0, there is a worker for each faucet account,
+	// kept in sync as the faucet keyring is rotated
+	QueueWorkers uint64 `toml:"queue_workers"`
+
+	// The max number of drips waiting in the queue.
+	// Drips are rejected while the queue is full
+	MaxQueueDepth uint64 `toml:"max_queue_depth"`
+
+	// The period the cached faucet accounts are refreshed from the chain.
+	// In between refreshes, the cached accounts are updated locally after
+	// each drip. Account caching is disabled if the interval is 0
+	AccountRefreshInterval time.Duration `toml:"account_refresh_interval"`
+
+	// The period the faucet account balances are checked for rebalancing.
+	// The treasury account tops up the other faucet accounts that fall
+	// under the low-water mark, and doesn't serve drips itself.
+	// Rebalancing is disabled if the interval is 0
+	RebalanceInterval time.Duration `toml:"rebalance_interval"`
+
+	// The index of the treasury faucet account, in the faucet keyring
+	// (for mnemonic keys, the account at hd start index + x)
+	TreasuryAccount uint64 `toml:"treasury_account"`
+
+	// The balance under which a faucet account is topped up.
+	// Format should be: <AMOUNT><DENOM>[,<AMOUNT><DENOM>...]
+	LowWaterMark string `toml:"low_water_mark"`
+
+	// The amount a faucet account is topped up with.
+	// Format should be: <AMOUNT><DENOM>[,<AMOUNT><DENOM>...]
+	TopUpAmount string `toml:"top_up_amount"`
 }
 
 // DefaultConfig returns the default faucet configuration
 func DefaultConfig() *Config {
 	return &Config{
-		ListenAddress: DefaultListenAddress,
-		ChainID:       DefaultChainID,
-		MaxSendAmount: DefaultMaxSendAmount,
-		Mnemonic:      DefaultMnemonic,
-		NumAccounts:   DefaultNumAccounts,
-		CORSConfig:    DefaultCORSConfig(),
+		ListenAddress:    DefaultListenAddress,
+		ChainID:          DefaultChainID,
+		MaxSendAmount:    DefaultMaxSendAmount,
+		Mnemonic:         DefaultMnemonic,
+		NumAccounts:      DefaultNumAccounts,
+		AccountSelection: DefaultAccountSelection,
+		BroadcastMode:    DefaultBroadcastMode,
+		DripMessage:      DefaultDripMessage,
+		MaxBatchSize:     DefaultMaxBatchSize,
+		MaxQueueDepth:    DefaultMaxQueueDepth,
+		LowWaterMark:     DefaultLowWaterMark,
+		TopUpAmount:      DefaultTopUpAmount,
+		FeeBumpExpiry:    DefaultFeeBumpExpiry,
+		CORSConfig:       DefaultCORSConfig(),
 	}
 }
 
-// ValidateConfig validates the faucet configuration
+// ValidateConfig validates the faucet configuration,
+// for faucet keys derived from the mnemonic
 func ValidateConfig(config *Config) error {
+	if err := ValidateServiceConfig(config); err != nil {
+		return err
+	}
+
+	if err := ValidateKeyDerivation(config); err != nil {
+		return err
+	}
+
+	return ValidateTreasuryAccount(config, config.NumAccounts)
+}
+
+// ValidateKeyDerivation validates the faucet key derivation
+// configuration (the mnemonic, the number of faucet accounts and the HD path).
+// It doesn't apply to faucet keys loaded from another source
+func ValidateKeyDerivation(config *Config) error {
+	// validate the mnemonic is bip39-compliant
+	if !bip39.IsMnemonicValid(config.Mnemonic) {
+		return fmt.Errorf("%w, %s", ErrInvalidMnemonic, config.Mnemonic)
+	}
+
+	// validate at least one faucet account is set
+	if config.NumAccounts < 1 {
+		return ErrInvalidNumAccounts
+	}
+
+	// validate the HD derivation path is non-hardened
+	if config.HDAccount > maxHDIndex {
+		return fmt.Errorf("%w, %d", ErrInvalidHDAccount, config.HDAccount)
+	}
+
+	if config.HDStartIndex > maxHDIndex || config.NumAccounts-1 > maxHDIndex-config.HDStartIndex {
+		return fmt.Errorf("%w, %d", ErrInvalidHDStartIndex, config.HDStartIndex)
+	}
+
+	return nil
+}
+
+// ValidateTreasuryAccount validates the treasury account is one
+// of the given number of faucet accounts, if rebalancing is enabled,
+// and there are other faucet accounts to top up
+func ValidateTreasuryAccount(config *Config, numAccounts uint64) error {
+	if config.RebalanceInterval > 0 &&
+		(numAccounts < 2 || config.TreasuryAccount >= numAccounts) {
+		return fmt.Errorf("%w, %d", ErrInvalidTreasuryAccount, config.TreasuryAccount)
+	}
+
+	return nil
+}
+
+// ValidateServiceConfig validates the faucet configuration, apart from
+// the key derivation and the treasury account, which depend on the faucet keys
+func ValidateServiceConfig(config *Config) error {
 	// validate the listen address
 	if !listenAddressRegex.MatchString(config.ListenAddress) {
 		return ErrInvalidListenAddress
@@ -79,19 +301,128 @@ func ValidateConfig(config *Config) error {
 	}
 
 	// validate the send amount
-	if !amountRegex.MatchString(config.MaxSendAmount) {
+	if !isValidAmount(config.MaxSendAmount) {
 		return ErrInvalidSendAmount
 	}
 
-	// validate the mnemonic is bip39-compliant
-	if !bip39.IsMnemonicValid(config.Mnemonic) {
-		return fmt.Errorf("%w, %s", ErrInvalidMnemonic, config.Mnemonic)
+	// validate the min send amount, if any
+	if config.MinSendAmount != "" {
+		if !isValidAmount(config.MinSendAmount) {
+			return ErrInvalidMinSendAmount
+		}
+
+		var (
+			maxAmount, _ = std.ParseCoins(config.MaxSendAmount) //nolint:errcheck // validated above
+			minAmount, _ = std.ParseCoins(config.MinSendAmount) //nolint:errcheck // validated above
+		)
+
+		if !minAmount.IsAllLTE(maxAmount) {
+			return fmt.Errorf("%w, exceeds the max send amount", ErrInvalidMinSendAmount)
+		}
 	}
 
-	// validate at least one faucet account is set
-	if config.NumAccounts < 1 {
-		return ErrInvalidNumAccounts
+	// validate the account selection strategy
+	switch config.AccountSelection {
+	case AccountSelectionRoundRobin,
+		AccountSelectionLRU,
+		AccountSelectionHighestBalance,
+		AccountSelectionRandom:
+	default:
+		return fmt.Errorf("%w, %s", ErrInvalidSelection, config.AccountSelection)
+	}
+
+	// validate the broadcast mode
+	if config.BroadcastMode != BroadcastModeCommit && config.BroadcastMode != BroadcastModeSync {
+		return fmt.Errorf("%w, %s", ErrInvalidBroadcastMode, config.BroadcastMode)
+	}
+
+	// validate the drip message type
+	switch config.DripMessage {
+	case DripMessageSend:
+	case DripMessageCall:
+		// validate the realm call is set
+		if config.RealmCall == nil || config.RealmCall.PkgPath == "" || config.RealmCall.Func == "" {
+			return fmt.Errorf("%w, realm path and function are required", ErrInvalidRealmCall)
+		}
+	default:
+		return fmt.Errorf("%w, %s", ErrInvalidDripMessage, config.DripMessage)
+	}
+
+	// validate the multisig accounts, if any
+	for i, account := range config.MultisigAccounts {
+		if account.Threshold < 1 || account.Threshold > uint64(len(account.PubKeys)) {
+			return fmt.Errorf("%w %d, invalid threshold %d", ErrInvalidMultisigAccount, i, account.Threshold)
+		}
+
+		for _, pubKey := range account.PubKeys {
+			if _, err := crypto.PubKeyFromBech32(pubKey); err != nil {
+				return fmt.Errorf("%w %d, %w", ErrInvalidMultisigAccount, i, err)
+			}
+		}
+	}
+
+	// validate the memo template, if any
+	if _, err := template.New("memo").Parse(config.MemoTemplate); err != nil {
+		return fmt.Errorf("%w, %w", ErrInvalidMemoTemplate, err)
+	}
+
+	// validate the fee bump factor and cap, if any
+	if config.FeeBumpFactor != 0 {
+		if config.FeeBumpFactor <= 1 {
+			return fmt.Errorf("%w, should be greater than 1", ErrInvalidFeeBumpFactor)
+		}
+
+		maxGasFee, err := std.ParseCoin(config.MaxGasFee)
+		if err != nil || !maxGasFee.IsPositive() {
+			return ErrInvalidMaxGasFee
+		}
+
+		if config.FeeBumpExpiry <= 0 {
+			return ErrInvalidFeeBumpExpiry
+		}
+	}
+
+	// validate the batch window
+	if config.BatchWindow < 0 {
+		return ErrInvalidBatchWindow
+	}
+
+	// validate at least one drip fits in a batch
+	if config.MaxBatchSize < 1 {
+		return ErrInvalidMaxBatchSize
+	}
+
+	// validate at least one drip fits in the queue
+	if config.MaxQueueDepth < 1 {
+		return ErrInvalidMaxQueueDepth
+	}
+
+	// validate the account refresh interval
+	if config.AccountRefreshInterval < 0 {
+		return ErrInvalidRefreshInterval
+	}
+
+	// validate the rebalance interval
+	if config.RebalanceInterval < 0 {
+		return ErrInvalidRebalanceInterval
+	}
+
+	// validate the low-water mark
+	if !isValidAmount(config.LowWaterMark) {
+		return ErrInvalidLowWaterMark
+	}
+
+	// validate the top-up amount
+	if !isValidAmount(config.TopUpAmount) {
+		return ErrInvalidTopUpAmount
 	}
 
 	return nil
 }
+
+// isValidAmount checks if the amount is a valid, non-empty coin list
+func isValidAmount(amount string) bool {
+	coins, err := std.ParseCoins(amount)
+
+	return err == nil && !coins.IsZero()
+}
@@ -2,7 +2,10 @@ package config
 
 import (
 	"testing"
+	"time"
 
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,11 +34,44 @@ func TestConfig_ValidateConfig(t *testing.T) {
 		t.Parallel()
 
 		cfg := DefaultConfig()
-		cfg.MaxSendAmount = "1000goo" // invalid denom
+		cfg.MaxSendAmount = "1000ugnot,-5foo" // invalid amount
 
 		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidSendAmount)
 	})
 
+	t.Run("invalid min send amount", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			name          string
+			minSendAmount string
+		}{
+			{
+				"invalid format",
+				"ugnot",
+			},
+			{
+				"unsupported denom",
+				"10utest",
+			},
+			{
+				"above max send amount",
+				"2000000ugnot",
+			},
+		}
+
+		for _, testCase := range testTable {
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				cfg := DefaultConfig()
+				cfg.MinSendAmount = testCase.minSendAmount
+
+				assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidMinSendAmount)
+			})
+		}
+	})
+
 	t.Run("invalid mnemonic", func(t *testing.T) {
 		t.Parallel()
 
@@ -54,9 +90,292 @@ func TestConfig_ValidateConfig(t *testing.T) {
 		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidNumAccounts)
 	})
 
+	t.Run("invalid HD account", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.HDAccount = 1 << 31 // hardened
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidHDAccount)
+	})
+
+	t.Run("invalid HD start index", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			name        string
+			startIndex  uint64
+			numAccounts uint64
+		}{
+			{
+				"hardened start index",
+				1 << 31,
+				1,
+			},
+			{
+				"hardened last index",
+				1<<31 - 2,
+				3,
+			},
+		}
+
+		for _, testCase := range testTable {
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				cfg := DefaultConfig()
+				cfg.HDStartIndex = testCase.startIndex
+				cfg.NumAccounts = testCase.numAccounts
+
+				assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidHDStartIndex)
+			})
+		}
+	})
+
+	t.Run("invalid account selection", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.AccountSelection = "first-come" // unknown strategy
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidSelection)
+	})
+
+	t.Run("invalid broadcast mode", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.BroadcastMode = "async" // unsupported mode
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidBroadcastMode)
+	})
+
+	t.Run("invalid batch window", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.BatchWindow = -time.Second // negative window
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidBatchWindow)
+	})
+
+	t.Run("invalid max batch size", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.MaxBatchSize = 0 // no drips fit
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidMaxBatchSize)
+	})
+
+	t.Run("invalid max queue depth", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.MaxQueueDepth = 0 // no drips fit
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidMaxQueueDepth)
+	})
+
+	t.Run("invalid account refresh interval", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.AccountRefreshInterval = -time.Second // negative interval
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidRefreshInterval)
+	})
+
+	t.Run("invalid rebalance interval", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.RebalanceInterval = -time.Second // negative interval
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidRebalanceInterval)
+	})
+
+	t.Run("invalid treasury account", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			name            string
+			numAccounts     uint64
+			treasuryAccount uint64
+		}{
+			{
+				"single faucet account",
+				1,
+				0,
+			},
+			{
+				"treasury out of range",
+				2,
+				2,
+			},
+		}
+
+		for _, testCase := range testTable {
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				cfg := DefaultConfig()
+				cfg.RebalanceInterval = time.Minute
+				cfg.NumAccounts = testCase.numAccounts
+				cfg.TreasuryAccount = testCase.treasuryAccount
+
+				assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidTreasuryAccount)
+			})
+		}
+	})
+
+	t.Run("invalid low-water mark", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.LowWaterMark = "10" // missing denom
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidLowWaterMark)
+	})
+
+	t.Run("invalid top-up amount", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.TopUpAmount = "ugnot" // missing amount
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidTopUpAmount)
+	})
+
+	t.Run("invalid drip message", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.DripMessage = "deploy" // unsupported message
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidDripMessage)
+	})
+
+	t.Run("invalid realm call", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.DripMessage = DripMessageCall
+		cfg.RealmCall = &RealmCall{
+			PkgPath: "gno.land/r/demo/foo20", // missing function
+		}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidRealmCall)
+	})
+
+	t.Run("invalid multisig threshold", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.MultisigAccounts = []MultisigAccount{
+			{
+				Threshold: 2, // exceeds the number of members
+				PubKeys: []string{
+					crypto.PubKeyToBech32(secp256k1.GenPrivKey().PubKey()),
+				},
+			},
+		}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidMultisigAccount)
+	})
+
+	t.Run("invalid multisig public key", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.MultisigAccounts = []MultisigAccount{
+			{
+				Threshold: 1,
+				PubKeys:   []string{"gpub1invalid"},
+			},
+		}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidMultisigAccount)
+	})
+
+	t.Run("invalid memo template", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.MemoTemplate = "faucet {{.RequestID" // unclosed action
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidMemoTemplate)
+	})
+
+	t.Run("invalid fee bump factor", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.FeeBumpFactor = 0.5 // lowers the fee
+		cfg.MaxGasFee = "10000000ugnot"
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidFeeBumpFactor)
+	})
+
+	t.Run("invalid max gas fee", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.FeeBumpFactor = 1.5
+		cfg.MaxGasFee = "" // missing cap
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidMaxGasFee)
+	})
+
+	t.Run("invalid fee bump expiry", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.FeeBumpFactor = 1.5
+		cfg.MaxGasFee = "10000000ugnot"
+		cfg.FeeBumpExpiry = 0 // bumped fees never dropped
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidFeeBumpExpiry)
+	})
+
+	t.Run("valid multi-denom send amount", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.MaxSendAmount = "1000ugnot,500utest"
+
+		assert.NoError(t, ValidateConfig(cfg))
+	})
+
 	t.Run("valid configuration", func(t *testing.T) {
 		t.Parallel()
 
 		assert.NoError(t, ValidateConfig(DefaultConfig()))
 	})
 }
+
+func TestConfig_ValidateServiceConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("key derivation ignored", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.Mnemonic = ""   // keys loaded from another source
+		cfg.NumAccounts = 0 // unknown until the keys are loaded
+
+		assert.NoError(t, ValidateServiceConfig(cfg))
+		assert.ErrorIs(t, ValidateKeyDerivation(cfg), ErrInvalidMnemonic)
+	})
+
+	t.Run("invalid treasury account", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.RebalanceInterval = time.Hour
+		cfg.TreasuryAccount = 2
+
+		assert.NoError(t, ValidateTreasuryAccount(cfg, 3))
+		assert.ErrorIs(t, ValidateTreasuryAccount(cfg, 2), ErrInvalidTreasuryAccount)
+	})
+}
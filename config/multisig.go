@@ -0,0 +1,12 @@
+package config
+
+// MultisigAccount defines a K of N threshold multisig faucet account,
+// for which the faucet holds (at least) the threshold of member keys
+type MultisigAccount struct {
+	// The number of member signatures required (K)
+	Threshold uint64 `toml:"threshold"`
+
+	// The bech32 member public keys (N), in multisig order.
+	// The order is part of the multisig address
+	PubKeys []string `toml:"pub_keys"`
+}
@@ -0,0 +1,143 @@
+package dynamic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gnolang/faucet/client"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const (
+	DefaultInterval    = 30 * time.Second
+	DefaultTimeout     = 10 * time.Second
+	DefaultPriceMargin = int64(10)
+)
+
+var errInvalidGasPrice = errors.New("invalid gas price")
+
+// Estimator is a dynamic gas estimator.
+// The gas fee is computed from the chain's latest gas price (with a safety
+// margin), for the transaction gas wanted. The gas price is periodically fetched
+// from the node, and the fallback gas price is used if it can't be fetched
+type Estimator struct {
+	client client.Client
+
+	gasWanted     int64        // the static gas wanted
+	gasPerMessage int64        // the additional gas wanted for each message past the first
+	fallbackPrice std.GasPrice // the gas price used if the chain gas price can't be fetched
+	priceMargin   int64        // the safety margin over the chain gas price, in percent
+
+	interval time.Duration // the gas price refresh interval
+	timeout  time.Duration // the gas price fetch timeout
+
+	price  std.GasPrice // the latest gas price
+	margin int64        // the safety margin over the latest gas price, in percent
+	mux    sync.RWMutex
+}
+
+// New creates a new dynamic gas estimator.
+// Until the gas price is first fetched, the fallback gas price is used
+func New(client client.Client, fallbackPrice std.GasPrice, gasWanted int64, opts ...Option) *Estimator {
+	e := &Estimator{
+		client:        client,
+		gasWanted:     gasWanted,
+		fallbackPrice: fallbackPrice,
+		priceMargin:   DefaultPriceMargin,
+		interval:      DefaultInterval,
+		timeout:       DefaultTimeout,
+		price:         fallbackPrice,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Run periodically refreshes the gas price,
+// until the context is done [BLOCKING]
+func (e *Estimator) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Estimator) EstimateGasFee(gasWanted int64) std.Coin {
+	e.mux.RLock()
+	price, margin := e.price, e.margin
+	e.mux.RUnlock()
+
+	return computeFee(price, max(gasWanted, e.gasWanted), margin)
+}
+
+func (e *Estimator) EstimateGasWanted(_ context.Context, tx *std.Tx) int64 {
+	extraMsgs := max(len(tx.Msgs)-1, 0)
+
+	return e.gasWanted + e.gasPerMessage*int64(extraMsgs)
+}
+
+// refresh fetches the latest gas price from the chain,
+// and falls back to the fallback gas price if it can't.
+// The chain gas price only reflects the latest block, so
+// the safety margin covers price increases until the next refresh
+func (e *Estimator) refresh(ctx context.Context) {
+	price, err := e.fetchPrice(ctx)
+	margin := e.priceMargin
+
+	if err != nil {
+		price = e.fallbackPrice
+		margin = 0
+	}
+
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	e.price = price
+	e.margin = margin
+}
+
+// fetchPrice fetches the latest gas price from the chain
+func (e *Estimator) fetchPrice(ctx context.Context) (std.GasPrice, error) {
+	ctx, cancelFn := context.WithTimeout(ctx, e.timeout)
+	defer cancelFn()
+
+	price, err := e.client.GetGasPrice(ctx)
+	if err != nil {
+		return std.GasPrice{}, fmt.Errorf("unable to fetch gas price, %w", err)
+	}
+
+	// The chain reports an empty gas price
+	// if it's not initialized yet
+	if price.Gas <= 0 || !price.Price.IsValid() {
+		return std.GasPrice{}, errInvalidGasPrice
+	}
+
+	return price, nil
+}
+
+// computeFee computes the fee for the given gas wanted, with the given
+// margin (in percent), rounded up so the fee always covers the gas price
+func computeFee(price std.GasPrice, gasWanted, margin int64) std.Coin {
+	var (
+		numerator   = gasWanted * price.Price.Amount * (100 + margin)
+		denominator = price.Gas * 100
+	)
+
+	amount := (numerator + denominator - 1) / denominator
+
+	return std.NewCoin(price.Price.Denom, amount)
+}
@@ -0,0 +1,236 @@
+package dynamic
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimator_EstimateGasFee(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gasWanted     = int64(100000)
+		fallbackPrice = std.GasPrice{
+			Gas:   1000,
+			Price: std.NewCoin("ugnot", 1),
+		}
+	)
+
+	t.Run("fallback price before refresh", func(t *testing.T) {
+		t.Parallel()
+
+		e := New(&mockClient{}, fallbackPrice, gasWanted)
+
+		// 100000 gas * 1ugnot / 1000 gas
+		assert.Equal(t, std.NewCoin("ugnot", 100), e.EstimateGasFee(gasWanted))
+		assert.Equal(t, gasWanted, e.EstimateGasWanted(context.Background(), &std.Tx{}))
+	})
+
+	t.Run("transaction gas wanted priced", func(t *testing.T) {
+		t.Parallel()
+
+		e := New(&mockClient{}, fallbackPrice, gasWanted)
+
+		// 300000 gas * 1ugnot / 1000 gas
+		assert.Equal(t, std.NewCoin("ugnot", 300), e.EstimateGasFee(300000))
+
+		// Make sure gas wanted below the static
+		// gas wanted is priced as the static gas wanted
+		assert.Equal(t, std.NewCoin("ugnot", 100), e.EstimateGasFee(0))
+	})
+
+	t.Run("chain price used", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockClient{
+			getGasPriceFn: func(_ context.Context) (std.GasPrice, error) {
+				return std.GasPrice{
+					Gas:   1000,
+					Price: std.NewCoin("ugnot", 3),
+				}, nil
+			},
+		}
+
+		e := New(mockClient, fallbackPrice, gasWanted, WithPriceMargin(0))
+		e.refresh(context.Background())
+
+		// 100000 gas * 3ugnot / 1000 gas
+		assert.Equal(t, std.NewCoin("ugnot", 300), e.EstimateGasFee(gasWanted))
+	})
+
+	t.Run("chain price margin applied", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockClient{
+			getGasPriceFn: func(_ context.Context) (std.GasPrice, error) {
+				return std.GasPrice{
+					Gas:   1000,
+					Price: std.NewCoin("ugnot", 3),
+				}, nil
+			},
+		}
+
+		e := New(mockClient, fallbackPrice, gasWanted)
+		e.refresh(context.Background())
+
+		// 100000 gas * 3ugnot / 1000 gas, with the default 10% margin
+		assert.Equal(t, std.NewCoin("ugnot", 330), e.EstimateGasFee(gasWanted))
+	})
+
+	t.Run("fee rounded up", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockClient{
+			getGasPriceFn: func(_ context.Context) (std.GasPrice, error) {
+				return std.GasPrice{
+					Gas:   3,
+					Price: std.NewCoin("ugnot", 1),
+				}, nil
+			},
+		}
+
+		e := New(mockClient, fallbackPrice, 10, WithPriceMargin(0))
+		e.refresh(context.Background())
+
+		// 10 gas * 1ugnot / 3 gas, rounded up
+		assert.Equal(t, std.NewCoin("ugnot", 4), e.EstimateGasFee(10))
+	})
+
+	t.Run("fallback price on fetch error", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			fail atomic.Bool
+
+			mockClient = &mockClient{
+				getGasPriceFn: func(_ context.Context) (std.GasPrice, error) {
+					if fail.Load() {
+						return std.GasPrice{}, errors.New("unable to fetch")
+					}
+
+					return std.GasPrice{
+						Gas:   1000,
+						Price: std.NewCoin("ugnot", 3),
+					}, nil
+				},
+			}
+		)
+
+		e := New(mockClient, fallbackPrice, gasWanted)
+
+		e.refresh(context.Background())
+		require.Equal(t, std.NewCoin("ugnot", 330), e.EstimateGasFee(gasWanted))
+
+		// Make sure the fallback price is used (without
+		// the margin) once the price can't be fetched
+		fail.Store(true)
+		e.refresh(context.Background())
+
+		assert.Equal(t, std.NewCoin("ugnot", 100), e.EstimateGasFee(gasWanted))
+	})
+
+	t.Run("fallback price on empty chain price", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockClient{
+			getGasPriceFn: func(_ context.Context) (std.GasPrice, error) {
+				return std.GasPrice{}, nil
+			},
+		}
+
+		e := New(mockClient, fallbackPrice, gasWanted)
+		e.refresh(context.Background())
+
+		assert.Equal(t, std.NewCoin("ugnot", 100), e.EstimateGasFee(gasWanted))
+	})
+}
+
+func TestEstimator_EstimateGasWanted(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gasWanted     = int64(100000)
+		gasPerMessage = int64(50000)
+
+		fallbackPrice = std.GasPrice{
+			Gas:   1000,
+			Price: std.NewCoin("ugnot", 1),
+		}
+	)
+
+	e := New(
+		&mockClient{},
+		fallbackPrice,
+		gasWanted,
+		WithGasPerMessage(gasPerMessage),
+	)
+
+	// Make sure the static gas wanted covers a single message
+	assert.Equal(t, gasWanted, e.EstimateGasWanted(context.Background(), &std.Tx{Msgs: make([]std.Msg, 1)}))
+
+	// Make sure each additional message adds to the gas wanted
+	assert.Equal(
+		t,
+		gasWanted+2*gasPerMessage,
+		e.EstimateGasWanted(context.Background(), &std.Tx{Msgs: make([]std.Msg, 3)}),
+	)
+}
+
+func TestEstimator_Run(t *testing.T) {
+	t.Parallel()
+
+	var (
+		fetches atomic.Int64
+
+		mockClient = &mockClient{
+			getGasPriceFn: func(_ context.Context) (std.GasPrice, error) {
+				fetches.Add(1)
+
+				return std.GasPrice{
+					Gas:   1000,
+					Price: std.NewCoin("ugnot", 5),
+				}, nil
+			},
+		}
+	)
+
+	e := New(
+		mockClient,
+		std.GasPrice{Gas: 1000, Price: std.NewCoin("ugnot", 1)},
+		100000,
+		WithInterval(10*time.Millisecond),
+		WithPriceMargin(0),
+	)
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- e.Run(ctx)
+	}()
+
+	// Make sure the price is refreshed periodically
+	assert.Eventually(t, func() bool {
+		return fetches.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, std.NewCoin("ugnot", 500), e.EstimateGasFee(100000))
+
+	// Make sure the estimator stops with the context
+	cancelFn()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("estimator did not stop")
+	}
+}
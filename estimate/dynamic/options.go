@@ -0,0 +1,36 @@
+package dynamic
+
+import "time"
+
+type Option func(e *Estimator)
+
+// WithInterval specifies the gas price refresh interval
+func WithInterval(interval time.Duration) Option {
+	return func(e *Estimator) {
+		e.interval = interval
+	}
+}
+
+// WithTimeout specifies the gas price fetch timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(e *Estimator) {
+		e.timeout = timeout
+	}
+}
+
+// WithPriceMargin specifies the safety margin over
+// the chain gas price, in percent. The margin doesn't
+// apply to the fallback gas price
+func WithPriceMargin(margin int64) Option {
+	return func(e *Estimator) {
+		e.priceMargin = margin
+	}
+}
+
+// WithGasPerMessage specifies the additional gas wanted for each
+// transaction message past the first, for multi-message transactions
+func WithGasPerMessage(gasPerMessage int64) Option {
+	return func(e *Estimator) {
+		e.gasPerMessage = gasPerMessage
+	}
+}
@@ -1,12 +1,21 @@
 package estimate
 
-import "github.com/gnolang/gno/tm2/pkg/std"
+import (
+	"context"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
 
 // Estimator defines the transaction gas estimator
 type Estimator interface {
-	// EstimateGasFee estimates the current network gas fee for transactions
-	EstimateGasFee() std.Coin
+	// EstimateGasFee estimates the current network gas fee for a transaction
+	// with the given gas wanted. Gas wanted below the estimator's base
+	// gas wanted is priced as the base gas wanted
+	EstimateGasFee(gasWanted int64) std.Coin
 
-	// EstimateGasWanted estimates the optimal gas wanted for the specified transaction
-	EstimateGasWanted(tx *std.Tx) int64
+	// EstimateGasWanted estimates the optimal gas wanted for the specified transaction.
+	// The transaction is not yet signed, and carries a placeholder
+	// signature with the signer public key. Estimations that query
+	// the network are bound to the given context
+	EstimateGasWanted(ctx context.Context, tx *std.Tx) int64
 }
@@ -0,0 +1,48 @@
+package simulate
+
+import (
+	"context"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type simulateTransactionDelegate func(context.Context, *std.Tx) (*abci.ResponseDeliverTx, error)
+
+type mockClient struct {
+	simulateTransactionFn simulateTransactionDelegate
+}
+
+func (m *mockClient) GetAccount(_ context.Context, _ crypto.Address) (std.Account, error) {
+	return nil, nil
+}
+
+func (m *mockClient) GetGasPrice(_ context.Context) (std.GasPrice, error) {
+	return std.GasPrice{}, nil
+}
+
+func (m *mockClient) SimulateTransaction(ctx context.Context, tx *std.Tx) (*abci.ResponseDeliverTx, error) {
+	if m.simulateTransactionFn != nil {
+		return m.simulateTransactionFn(ctx, tx)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) SendTransactionSync(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+	return nil, nil
+}
+
+func (m *mockClient) SendTransactionCommit(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+	return nil, nil
+}
+
+func (m *mockClient) GetTransaction(_ context.Context, _ []byte) (*coreTypes.ResultTx, error) {
+	return nil, nil
+}
+
+func (m *mockClient) Status(_ context.Context) (*coreTypes.ResultStatus, error) {
+	return nil, nil
+}
@@ -0,0 +1,35 @@
+package simulate
+
+import "time"
+
+type Option func(e *Estimator)
+
+// WithMultiplier specifies the safety multiplier
+// applied to the simulated gas used
+func WithMultiplier(multiplier float64) Option {
+	return func(e *Estimator) {
+		e.multiplier = multiplier
+	}
+}
+
+// WithFloor specifies the min gas wanted
+func WithFloor(floor int64) Option {
+	return func(e *Estimator) {
+		e.floor = floor
+	}
+}
+
+// WithCeiling specifies the max gas wanted,
+// which is also the gas limit for the simulation
+func WithCeiling(ceiling int64) Option {
+	return func(e *Estimator) {
+		e.ceiling = ceiling
+	}
+}
+
+// WithTimeout specifies the simulation timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(e *Estimator) {
+		e.timeout = timeout
+	}
+}
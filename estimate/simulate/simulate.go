@@ -0,0 +1,98 @@
+package simulate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gnolang/faucet/client"
+	"github.com/gnolang/faucet/estimate"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const (
+	DefaultMultiplier = 1.2
+	DefaultFloor      = int64(50000)
+	DefaultCeiling    = int64(10000000)
+	DefaultTimeout    = 10 * time.Second
+)
+
+var errSimulationFailed = errors.New("transaction failed simulation")
+
+// Estimator is a simulation-based gas estimator.
+// The gas wanted is the gas used by the transaction when simulated
+// on the node, with a safety multiplier, within the floor and ceiling
+type Estimator struct {
+	client client.Client
+	base   estimate.Estimator // the gas fee, and the fallback gas wanted
+
+	multiplier float64       // the safety multiplier for the simulated gas used
+	floor      int64         // the min gas wanted
+	ceiling    int64         // the max gas wanted, and the simulation gas limit
+	timeout    time.Duration // the simulation timeout
+}
+
+// New creates a new simulation-based gas estimator.
+// The gas fee is estimated by the base estimator, which also
+// estimates the gas wanted if the transaction can't be simulated
+func New(client client.Client, base estimate.Estimator, opts ...Option) *Estimator {
+	e := &Estimator{
+		client:     client,
+		base:       base,
+		multiplier: DefaultMultiplier,
+		floor:      DefaultFloor,
+		ceiling:    DefaultCeiling,
+		timeout:    DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+func (e *Estimator) EstimateGasFee(gasWanted int64) std.Coin {
+	return e.base.EstimateGasFee(gasWanted)
+}
+
+func (e *Estimator) EstimateGasWanted(ctx context.Context, tx *std.Tx) int64 {
+	gasUsed, err := e.simulate(ctx, tx)
+	if err != nil {
+		// The transaction can't be simulated, so rely on
+		// the base estimate. If the transaction would fail,
+		// it fails on its own once broadcast
+		return e.base.EstimateGasWanted(ctx, tx)
+	}
+
+	gasWanted := int64(math.Ceil(float64(gasUsed) * e.multiplier))
+
+	return min(max(gasWanted, e.floor), e.ceiling)
+}
+
+// simulate simulates the transaction on the node, and returns the gas used
+// by the transaction. The simulation is bound to the caller context
+func (e *Estimator) simulate(ctx context.Context, tx *std.Tx) (int64, error) {
+	ctx, cancelFn := context.WithTimeout(ctx, e.timeout)
+	defer cancelFn()
+
+	// Simulate the transaction with the ceiling as the gas limit,
+	// without modifying the original transaction. The simulation
+	// only pays the base gas fee, so it doesn't require the funds
+	// for the whole ceiling
+	simulatedTx := *tx
+	simulatedTx.Fee = std.NewFee(e.ceiling, e.base.EstimateGasFee(0))
+
+	response, err := e.client.SimulateTransaction(ctx, &simulatedTx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to simulate transaction, %w", err)
+	}
+
+	if response.IsErr() {
+		return 0, fmt.Errorf("%w, %w", errSimulationFailed, response.Error)
+	}
+
+	return response.GasUsed, nil
+}
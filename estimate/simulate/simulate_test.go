@@ -0,0 +1,162 @@
+package simulate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gnolang/faucet/estimate/static"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+
+	tm2Errors "github.com/gnolang/gno/tm2/pkg/bft/abci/example/errors"
+)
+
+func TestEstimator_EstimateGasWanted(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gasFee       = std.NewCoin("ugnot", 1000)
+		baseEstimate = int64(100000)
+
+		base = static.New(gasFee, baseEstimate)
+	)
+
+	// newSimulationClient creates a client whose
+	// simulation reports the given gas used
+	newSimulationClient := func(gasUsed int64) *mockClient {
+		return &mockClient{
+			simulateTransactionFn: func(_ context.Context, _ *std.Tx) (*abci.ResponseDeliverTx, error) {
+				return &abci.ResponseDeliverTx{
+					GasUsed: gasUsed,
+				}, nil
+			},
+		}
+	}
+
+	t.Run("multiplier applied", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			capturedTx *std.Tx
+
+			tx         = &std.Tx{Memo: "dummy tx"}
+			mockClient = &mockClient{
+				simulateTransactionFn: func(_ context.Context, tx *std.Tx) (*abci.ResponseDeliverTx, error) {
+					capturedTx = tx
+
+					return &abci.ResponseDeliverTx{
+						GasUsed: 100000,
+					}, nil
+				},
+			}
+		)
+
+		e := New(mockClient, base, WithMultiplier(1.5), WithCeiling(1000000))
+
+		assert.Equal(t, int64(150000), e.EstimateGasWanted(context.Background(), tx))
+
+		// Make sure the transaction was simulated
+		// with the ceiling as the gas limit
+		assert.Equal(t, tx.Memo, capturedTx.Memo)
+		assert.Equal(t, std.NewFee(1000000, gasFee), capturedTx.Fee)
+
+		// Make sure the original transaction is untouched
+		assert.Equal(t, std.Fee{}, tx.Fee)
+	})
+
+	t.Run("floor applied", func(t *testing.T) {
+		t.Parallel()
+
+		e := New(newSimulationClient(100), base, WithFloor(20000))
+
+		assert.Equal(t, int64(20000), e.EstimateGasWanted(context.Background(), &std.Tx{}))
+	})
+
+	t.Run("ceiling applied", func(t *testing.T) {
+		t.Parallel()
+
+		e := New(newSimulationClient(900000), base, WithCeiling(1000000))
+
+		assert.Equal(t, int64(1000000), e.EstimateGasWanted(context.Background(), &std.Tx{}))
+	})
+
+	t.Run("unable to simulate", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockClient{
+			simulateTransactionFn: func(_ context.Context, _ *std.Tx) (*abci.ResponseDeliverTx, error) {
+				return nil, errors.New("unable to simulate")
+			},
+		}
+
+		e := New(mockClient, base)
+
+		// Make sure the base estimate is used
+		assert.Equal(t, baseEstimate, e.EstimateGasWanted(context.Background(), &std.Tx{}))
+	})
+
+	t.Run("simulation bound to the caller context", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockClient{
+			simulateTransactionFn: func(ctx context.Context, _ *std.Tx) (*abci.ResponseDeliverTx, error) {
+				// The node never responds
+				<-ctx.Done()
+
+				return nil, ctx.Err()
+			},
+		}
+
+		e := New(mockClient, base, WithTimeout(time.Minute))
+
+		ctx, cancelFn := context.WithCancel(context.Background())
+		cancelFn()
+
+		// Make sure the canceled caller doesn't wait for the simulation timeout
+		estimated := make(chan int64, 1)
+
+		go func() {
+			estimated <- e.EstimateGasWanted(ctx, &std.Tx{})
+		}()
+
+		select {
+		case gasWanted := <-estimated:
+			assert.Equal(t, baseEstimate, gasWanted)
+		case <-time.After(5 * time.Second):
+			t.Fatal("simulation not canceled")
+		}
+	})
+
+	t.Run("failed simulation", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockClient{
+			simulateTransactionFn: func(_ context.Context, _ *std.Tx) (*abci.ResponseDeliverTx, error) {
+				return &abci.ResponseDeliverTx{
+					ResponseBase: abci.ResponseBase{
+						Error: tm2Errors.UnauthorizedError{},
+					},
+					GasUsed: 500,
+				}, nil
+			},
+		}
+
+		e := New(mockClient, base)
+
+		// Make sure the base estimate is used
+		assert.Equal(t, baseEstimate, e.EstimateGasWanted(context.Background(), &std.Tx{}))
+	})
+}
+
+func TestEstimator_EstimateGasFee(t *testing.T) {
+	t.Parallel()
+
+	gasFee := std.NewCoin("ugnot", 1000)
+
+	e := New(&mockClient{}, static.New(gasFee, 0))
+
+	assert.Equal(t, gasFee, e.EstimateGasFee(100000))
+}
@@ -0,0 +1,11 @@
+package static
+
+type Option func(e *Estimator)
+
+// WithGasPerMessage specifies the additional gas wanted for each
+// transaction message past the first, for multi-message transactions
+func WithGasPerMessage(gasPerMessage int64) Option {
+	return func(e *Estimator) {
+		e.gasPerMessage = gasPerMessage
+	}
+}
@@ -1,27 +1,49 @@
 package static
 
 import (
+	"context"
+
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
-// Estimator is a static gas estimator (returns static values)
+// Estimator is a static gas estimator (returns static values).
+// The static gas fee covers the static gas wanted, and is scaled
+// proportionally for transactions that want more gas
+// (for example, multi-message transactions)
 type Estimator struct {
-	gasFee    std.Coin
-	gasWanted int64
+	gasFee        std.Coin
+	gasWanted     int64
+	gasPerMessage int64
 }
 
 // New creates a new static gas estimator
-func New(gasFee std.Coin, gasWanted int64) *Estimator {
-	return &Estimator{
-		gasFee:    gasFee,
-		gasWanted: gasWanted,
+func New(gasFee std.Coin, gasWanted int64, opts ...Option) *Estimator {
+	e := &Estimator{
+		gasFee:        gasFee,
+		gasWanted:     gasWanted,
+		gasPerMessage: 0, // the static gas wanted covers the whole transaction
+	}
+
+	for _, opt := range opts {
+		opt(e)
 	}
+
+	return e
 }
 
-func (e Estimator) EstimateGasFee() std.Coin {
-	return e.gasFee
+func (e Estimator) EstimateGasFee(gasWanted int64) std.Coin {
+	if gasWanted <= e.gasWanted || e.gasWanted <= 0 {
+		return e.gasFee
+	}
+
+	// Scale the fee to the gas wanted, rounded up
+	amount := (e.gasFee.Amount*gasWanted + e.gasWanted - 1) / e.gasWanted
+
+	return std.NewCoin(e.gasFee.Denom, amount)
 }
 
-func (e Estimator) EstimateGasWanted(_ *std.Tx) int64 {
-	return e.gasWanted
+func (e Estimator) EstimateGasWanted(_ context.Context, tx *std.Tx) int64 {
+	extraMsgs := max(len(tx.Msgs)-1, 0)
+
+	return e.gasWanted + e.gasPerMessage*int64(extraMsgs)
 }
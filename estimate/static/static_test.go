@@ -0,0 +1,33 @@
+package static
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimator_EstimateGasFee(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gasFee        = std.NewCoin("ugnot", 100)
+		gasWanted     = int64(100000)
+		gasPerMessage = int64(50000)
+
+		e = New(gasFee, gasWanted, WithGasPerMessage(gasPerMessage))
+	)
+
+	// Make sure the static gas wanted is priced at the static fee
+	assert.Equal(t, gasFee, e.EstimateGasFee(0))
+	assert.Equal(t, gasFee, e.EstimateGasFee(gasWanted))
+
+	// Make sure the fee is scaled for each additional message
+	multiMsgGasWanted := e.EstimateGasWanted(context.Background(), &std.Tx{Msgs: make([]std.Msg, 3)})
+
+	assert.Equal(t, std.NewCoin("ugnot", 200), e.EstimateGasFee(multiMsgGasWanted))
+
+	// Make sure the scaled fee is rounded up
+	assert.Equal(t, std.NewCoin("ugnot", 101), e.EstimateGasFee(gasWanted+1))
+}
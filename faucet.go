@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"text/template"
 	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -18,8 +19,13 @@ import (
 	"github.com/gnolang/faucet/client"
 	"github.com/gnolang/faucet/config"
 	"github.com/gnolang/faucet/estimate"
-	"github.com/gnolang/faucet/keyring"
 	"github.com/gnolang/faucet/keyring/memory"
+	"github.com/gnolang/faucet/policy"
+	"github.com/gnolang/faucet/selector"
+	"github.com/gnolang/faucet/selector/balance"
+	"github.com/gnolang/faucet/selector/lru"
+	"github.com/gnolang/faucet/selector/random"
+	"github.com/gnolang/faucet/selector/roundrobin"
 )
 
 // Faucet is a standard Gno faucet
@@ -27,7 +33,16 @@ type Faucet struct {
 	estimator estimate.Estimator // gas pricing estimations
 	logger    *slog.Logger       // log feedback
 	client    client.Client      // TM2 client
-	keyring   keyring.Keyring    // the faucet keyring
+	keyring   *rotatingKeyring   // the faucet keyring, replaceable at runtime
+	sequencer *sequencer         // local account sequence tracking
+	selector  selector.Selector  // faucet account selection strategy
+	txTracker *txTracker         // pending (sync broadcast) tx tracking
+	batcher   *batcher           // drip batching, if enabled
+	queue     *dripQueue         // drip work queue
+
+	accountCache *accountCache // faucet account caching, if enabled
+	feeBumper    *feeBumper    // insufficient gas fee bumping, if enabled
+	rebalancer   *rebalancer   // faucet account rebalancing, if enabled
 
 	mux *chi.Mux // HTTP routing
 
@@ -37,9 +52,11 @@ type Faucet struct {
 	rpcMiddlewares  []Middleware                      // JSON-RPC request middlewares (Handler -> Handler)
 	rpcHandlers     []Handler                         // JSON-RPC request handlers
 
-	prepareTxMsgFn PrepareTxMessageFn // transaction message creator
+	prepareTxMsgsFn PrepareTxMessagesFn // transaction messages creator
+	memoTemplate    *template.Template  // transaction memo template, if any
 
-	maxSendAmount std.Coins // the max send amount per drip
+	maxSendAmount std.Coins      // the max send amount per drip
+	amountPolicy  *policy.Policy // the drip amount policy
 }
 
 var noopLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
@@ -55,7 +72,8 @@ func NewFaucet(
 		client:         client,
 		logger:         noopLogger,
 		config:         config.DefaultConfig(),
-		prepareTxMsgFn: defaultPrepareTxMessage,
+		sequencer:      newSequencer(),
+		txTracker:      newTxTracker(pendingTxRetention),
 		rpcMiddlewares: nil, // no middlewares by default
 
 		mux: chi.NewMux(),
@@ -74,17 +92,135 @@ func NewFaucet(
 		opt(f)
 	}
 
-	// Validate the configuration
-	if err := config.ValidateConfig(f.config); err != nil {
+	// Validate the configuration. The key derivation configuration
+	// only applies if the keyring is not provided
+	if err := config.ValidateServiceConfig(f.config); err != nil {
 		return nil, fmt.Errorf("invalid configuration, %w", err)
 	}
 
+	if f.keyring == nil {
+		if err := config.ValidateKeyDerivation(f.config); err != nil {
+			return nil, fmt.Errorf("invalid configuration, %w", err)
+		}
+	}
+
 	// Set the send amount
 	//nolint:errcheck // MaxSendAmount is validated beforehand
 	f.maxSendAmount, _ = std.ParseCoins(f.config.MaxSendAmount)
 
-	// Generate the in-memory keyring
-	f.keyring = memory.New(f.config.Mnemonic, f.config.NumAccounts)
+	// Set the drip amount policy
+	//nolint:errcheck // MinSendAmount is validated beforehand
+	minSendAmount, _ := std.ParseCoins(f.config.MinSendAmount)
+
+	f.amountPolicy = policy.New(f.maxSendAmount, minSendAmount)
+
+	// Set the drip message constructor, if not provided
+	if f.prepareTxMsgsFn == nil {
+		prepareTxMsgFn, err := newPrepareTxMessageFn(f.config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid drip message, %w", err)
+		}
+
+		f.prepareTxMsgsFn = singleMessage(prepareTxMsgFn)
+	}
+
+	// Set the transaction memo template, if any
+	if f.config.MemoTemplate != "" {
+		memoTemplate, err := newMemoTemplate(f.config.MemoTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memo template, %w", err)
+		}
+
+		f.memoTemplate = memoTemplate
+	}
+
+	// Generate the in-memory keyring, if not provided
+	if f.keyring == nil {
+		//nolint:gosec // the HD account and start index are validated beforehand
+		f.keyring = newRotatingKeyring(memory.New(
+			f.config.Mnemonic,
+			f.config.NumAccounts,
+			memory.WithAccount(uint32(f.config.HDAccount)),
+			memory.WithStartIndex(uint32(f.config.HDStartIndex)),
+			memory.WithPassphrase(f.config.MnemonicPassphrase),
+		))
+	}
+
+	// Set up the multisig faucet accounts, if any
+	if len(f.config.MultisigAccounts) > 0 {
+		k, err := withMultisigAccounts(f.keyring.active.keyring, f.config.MultisigAccounts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to set up multisig accounts, %w", err)
+		}
+
+		f.keyring = newRotatingKeyring(k)
+	}
+
+	// Validate the treasury account against the actual faucet accounts
+	numAccounts := uint64(len(f.keyring.GetAddresses()))
+
+	if err := config.ValidateTreasuryAccount(f.config, numAccounts); err != nil {
+		return nil, fmt.Errorf("invalid configuration, %w", err)
+	}
+
+	// Set up the drip queue, with a worker
+	// for each faucet account by default
+	numWorkers := f.config.QueueWorkers
+	if numWorkers == 0 {
+		numWorkers = numAccounts
+	}
+
+	//nolint:gosec // worker count and queue depth are reasonably small
+	f.queue = newDripQueue(
+		int(numWorkers),
+		int(f.config.MaxQueueDepth),
+		f.executeTransfers,
+	)
+
+	// Set up faucet account caching, if enabled
+	if f.config.AccountRefreshInterval > 0 {
+		f.accountCache = newAccountCache()
+	}
+
+	// Set up gas fee bumping, if enabled
+	if f.config.FeeBumpFactor > 0 {
+		//nolint:errcheck // MaxGasFee is validated beforehand
+		maxGasFee, _ := std.ParseCoin(f.config.MaxGasFee)
+
+		f.feeBumper = newFeeBumper(
+			f.estimator,
+			f.config.FeeBumpFactor,
+			maxGasFee,
+			f.config.FeeBumpExpiry,
+		)
+		f.estimator = f.feeBumper
+	}
+
+	// Set up faucet account rebalancing, if enabled
+	if f.config.RebalanceInterval > 0 {
+		//nolint:errcheck // LowWaterMark and TopUpAmount are validated beforehand
+		var (
+			lowWaterMark, _ = std.ParseCoins(f.config.LowWaterMark)
+			topUpAmount, _  = std.ParseCoins(f.config.TopUpAmount)
+		)
+
+		f.rebalancer = newRebalancer(f.config.TreasuryAccount, lowWaterMark, topUpAmount)
+	}
+
+	// Set up drip batching, if enabled.
+	// Batches are executed through the drip queue
+	if f.config.BatchWindow > 0 {
+		f.batcher = newBatcher(
+			f.config.BatchWindow,
+			int(f.config.MaxBatchSize), //nolint:gosec // batch size is reasonably small
+			f.queue.submit,
+		)
+	}
+
+	// Set the account selection strategy, if not provided
+	if f.selector == nil {
+		f.selector = newSelector(f.config.AccountSelection)
+	}
 
 	// Set up the CORS middleware
 	if f.config.CORSConfig != nil {
@@ -109,11 +245,19 @@ func NewFaucet(
 			r.Use(mw)
 		}
 
+		// Handle the batch requests concurrently only if
+		// drip batching is enabled, up to a full drip batch
+		maxConcurrent := 1
+		if f.config.BatchWindow > 0 {
+			maxConcurrent = int(f.config.MaxBatchSize) //nolint:gosec // batch size is reasonably small
+		}
+
 		// Apply JSON-RPC request middlewares
 		for _, h := range f.rpcHandlers {
 			r.Post(h.Pattern,
 				wrapJSONRPC(
 					h.HandlerFunc,
+					maxConcurrent,
 					f.rpcMiddlewares...,
 				),
 			)
@@ -123,6 +267,34 @@ func NewFaucet(
 	return f, nil
 }
 
+// newPrepareTxMessageFn creates the drip message constructor for the given config
+func newPrepareTxMessageFn(cfg *config.Config) (PrepareTxMessageFn, error) {
+	if cfg.DripMessage != config.DripMessageCall {
+		return defaultPrepareTxMessage, nil
+	}
+
+	return NewRealmCallMessageFn(
+		cfg.RealmCall.PkgPath,
+		cfg.RealmCall.Func,
+		cfg.RealmCall.Args,
+		cfg.RealmCall.AttachAmount,
+	)
+}
+
+// newSelector creates the account selector for the given strategy
+func newSelector(strategy string) selector.Selector {
+	switch strategy {
+	case config.AccountSelectionLRU:
+		return lru.New()
+	case config.AccountSelectionHighestBalance:
+		return balance.New()
+	case config.AccountSelectionRandom:
+		return random.New()
+	default:
+		return roundrobin.New()
+	}
+}
+
 // Serve serves the Gno faucet [BLOCKING]
 func (f *Faucet) Serve(ctx context.Context) error {
 	faucet := &http.Server{
@@ -155,6 +327,22 @@ func (f *Faucet) Serve(ctx context.Context) error {
 		return nil
 	})
 
+	if f.accountCache != nil {
+		group.Go(func() error {
+			f.refreshAccounts(gCtx, f.config.AccountRefreshInterval)
+
+			return nil
+		})
+	}
+
+	if f.rebalancer != nil {
+		group.Go(func() error {
+			f.runRebalancer(gCtx, f.config.RebalanceInterval)
+
+			return nil
+		})
+	}
+
 	group.Go(func() error {
 		<-gCtx.Done()
 
@@ -163,7 +351,12 @@ func (f *Faucet) Serve(ctx context.Context) error {
 		wsCtx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 		defer cancel()
 
-		return faucet.Shutdown(wsCtx)
+		shutdownErr := faucet.Shutdown(wsCtx)
+
+		// Drain the drips that are still queued
+		f.queue.close()
+
+		return shutdownErr
 	})
 
 	return group.Wait()
@@ -3,6 +3,7 @@ package faucet
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/selector/lru"
 	"github.com/gnolang/faucet/spec"
 )
 
@@ -114,6 +116,98 @@ func TestFaucet_NewFaucet(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("with keyring", func(t *testing.T) {
+		t.Parallel()
+
+		k := &mockKeyring{
+			getAddressesFn: func() []crypto.Address {
+				return []crypto.Address{{1}, {2}}
+			},
+		}
+
+		f, err := NewFaucet(
+			&mockEstimator{},
+			&mockClient{},
+			WithConfig(config.DefaultConfig()),
+			WithKeyring(k),
+		)
+
+		require.NotNil(t, f)
+		require.NoError(t, err)
+
+		assert.Equal(t, k, f.keyring.active.keyring)
+	})
+
+	t.Run("with keyring, without mnemonic", func(t *testing.T) {
+		t.Parallel()
+
+		// The key derivation configuration
+		// doesn't apply to the provided keyring
+		cfg := config.DefaultConfig()
+		cfg.Mnemonic = ""
+		cfg.NumAccounts = 0
+
+		f, err := NewFaucet(
+			&mockEstimator{},
+			&mockClient{},
+			WithConfig(cfg),
+			WithKeyring(newTestKeyring(crypto.Address{1}, crypto.Address{2})),
+		)
+
+		assert.NotNil(t, f)
+		assert.NoError(t, err)
+	})
+
+	t.Run("treasury account validated against the keyring", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.DefaultConfig()
+		cfg.RebalanceInterval = time.Hour
+		cfg.TreasuryAccount = 1
+
+		// The keyring holds 2 accounts, regardless of the configured number
+		f, err := NewFaucet(
+			&mockEstimator{},
+			&mockClient{},
+			WithConfig(cfg),
+			WithKeyring(newTestKeyring(crypto.Address{1}, crypto.Address{2})),
+		)
+
+		require.NoError(t, err)
+		require.NotNil(t, f)
+
+		// Make sure the treasury is one of the keyring accounts
+		cfg.TreasuryAccount = 2
+
+		f, err = NewFaucet(
+			&mockEstimator{},
+			&mockClient{},
+			WithConfig(cfg),
+			WithKeyring(newTestKeyring(crypto.Address{1}, crypto.Address{2})),
+		)
+
+		assert.Nil(t, f)
+		assert.ErrorIs(t, err, config.ErrInvalidTreasuryAccount)
+	})
+
+	t.Run("with account selector", func(t *testing.T) {
+		t.Parallel()
+
+		s := lru.New()
+
+		f, err := NewFaucet(
+			&mockEstimator{},
+			&mockClient{},
+			WithConfig(config.DefaultConfig()),
+			WithAccountSelector(s),
+		)
+
+		require.NotNil(t, f)
+		require.NoError(t, err)
+
+		assert.Equal(t, s, f.selector)
+	})
+
 	t.Run("with prepare transaction message callback", func(t *testing.T) {
 		t.Parallel()
 
@@ -149,10 +243,11 @@ func TestFaucet_NewFaucet(t *testing.T) {
 		require.NoError(t, err)
 
 		// Prepare the message
-		msg := f.prepareTxMsgFn(cfg)
+		msgs := f.prepareTxMsgsFn(cfg)
+		require.Len(t, msgs, 1)
 
 		// Validate the message
-		msgCall, ok := msg.(vm.MsgCall)
+		msgCall, ok := msgs[0].(vm.MsgCall)
 		require.True(t, ok)
 
 		assert.Equal(t, cfg.FromAddress, msgCall.Caller)
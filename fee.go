@@ -0,0 +1,114 @@
+package faucet
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gnolang/faucet/estimate"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// feeBumper bumps the gas fee of the drip transactions rejected
+// for an insufficient fee, up to the max gas fee. The latest accepted
+// bumped fee is the min gas fee estimate for subsequent drips
+// (scaled to their gas wanted), until the underlying estimate
+// catches up, or the bumped fee expires
+type feeBumper struct {
+	estimate.Estimator
+
+	factor float64       // the factor the fee is bumped by
+	maxFee std.Coin      // the hard cap for bumped fees
+	expiry time.Duration // the period an accepted bumped fee is kept for
+
+	minFee *bumpedFee // the latest accepted bumped fee, if any
+	mux    sync.Mutex
+}
+
+// bumpedFee is a bumped gas fee accepted by the network
+type bumpedFee struct {
+	fee       std.Coin  // the accepted fee
+	gasWanted int64     // the gas wanted of the accepted transaction
+	ratio     float64   // the accepted fee, relative to the estimated fee
+	expiresAt time.Time // the moment the bumped fee is dropped
+}
+
+// newFeeBumper creates a new gas fee bumper, on top of the given estimator
+func newFeeBumper(
+	estimator estimate.Estimator,
+	factor float64,
+	maxFee std.Coin,
+	expiry time.Duration,
+) *feeBumper {
+	return &feeBumper{
+		Estimator: estimator,
+		factor:    factor,
+		maxFee:    maxFee,
+		expiry:    expiry,
+	}
+}
+
+func (b *feeBumper) EstimateGasFee(gasWanted int64) std.Coin {
+	fee := b.Estimator.EstimateGasFee(gasWanted)
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if b.minFee == nil || b.minFee.fee.Denom != fee.Denom {
+		return fee
+	}
+
+	// Drop the min fee once it expires,
+	// or once the estimate catches up
+	if time.Now().After(b.minFee.expiresAt) ||
+		b.Estimator.EstimateGasFee(b.minFee.gasWanted).IsGTE(b.minFee.fee) {
+		b.minFee = nil
+
+		return fee
+	}
+
+	// Scale the estimate by the accepted fee ratio,
+	// within the max gas fee
+	amount := min(
+		int64(math.Ceil(float64(fee.Amount)*b.minFee.ratio)),
+		b.maxFee.Amount,
+	)
+
+	return std.NewCoin(fee.Denom, max(amount, fee.Amount))
+}
+
+// bump returns the bumped gas fee, capped at the max gas fee.
+// Fees that are already at the cap can't be bumped further
+func (b *feeBumper) bump(fee std.Coin) (std.Coin, bool) {
+	if fee.Denom != b.maxFee.Denom || fee.Amount >= b.maxFee.Amount {
+		return std.Coin{}, false
+	}
+
+	amount := max(
+		int64(math.Ceil(float64(fee.Amount)*b.factor)),
+		fee.Amount+1,
+	)
+
+	return std.NewCoin(fee.Denom, min(amount, b.maxFee.Amount)), true
+}
+
+// accept records the bumped gas fee accepted by the network, for
+// a transaction with the given gas wanted, as the min gas fee
+// estimate for subsequent drips
+func (b *feeBumper) accept(fee std.Coin, gasWanted int64) {
+	estimated := b.Estimator.EstimateGasFee(gasWanted)
+	if estimated.Denom != fee.Denom || !estimated.IsPositive() {
+		// The bumped fee can't be related to the estimate
+		return
+	}
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.minFee = &bumpedFee{
+		fee:       fee,
+		gasWanted: gasWanted,
+		ratio:     float64(fee.Amount) / float64(estimated.Amount),
+		expiresAt: time.Now().Add(b.expiry),
+	}
+}
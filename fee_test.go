@@ -0,0 +1,267 @@
+package faucet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnolang/faucet/config"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeBumper(t *testing.T) {
+	t.Parallel()
+
+	newBumper := func(fee std.Coin) *feeBumper {
+		return newFeeBumper(
+			&mockEstimator{
+				estimateGasFeeFn: func(_ int64) std.Coin {
+					return fee
+				},
+			},
+			1.5,
+			std.NewCoin("ugnot", 200),
+			time.Hour,
+		)
+	}
+
+	t.Run("fee bumped", func(t *testing.T) {
+		t.Parallel()
+
+		b := newBumper(std.NewCoin("ugnot", 100))
+
+		bumped, ok := b.bump(std.NewCoin("ugnot", 100))
+		require.True(t, ok)
+
+		assert.Equal(t, std.NewCoin("ugnot", 150), bumped)
+	})
+
+	t.Run("fee capped", func(t *testing.T) {
+		t.Parallel()
+
+		b := newBumper(std.NewCoin("ugnot", 100))
+
+		bumped, ok := b.bump(std.NewCoin("ugnot", 150))
+		require.True(t, ok)
+
+		assert.Equal(t, std.NewCoin("ugnot", 200), bumped)
+
+		// Make sure the capped fee can't be bumped further
+		_, ok = b.bump(bumped)
+		assert.False(t, ok)
+	})
+
+	t.Run("fee denomination mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		b := newBumper(std.NewCoin("ugnot", 100))
+
+		_, ok := b.bump(std.NewCoin("utest", 100))
+		assert.False(t, ok)
+	})
+
+	t.Run("accepted fee used as min fee", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			estimatedFee = std.NewCoin("ugnot", 100)
+
+			b = newFeeBumper(
+				&mockEstimator{
+					estimateGasFeeFn: func(_ int64) std.Coin {
+						return estimatedFee
+					},
+				},
+				1.5,
+				std.NewCoin("ugnot", 200),
+				time.Hour,
+			)
+		)
+
+		b.accept(std.NewCoin("ugnot", 150), 100000)
+
+		assert.Equal(t, std.NewCoin("ugnot", 150), b.EstimateGasFee(0))
+
+		// Make sure the min fee is dropped
+		// once the estimate catches up
+		estimatedFee = std.NewCoin("ugnot", 160)
+		assert.Equal(t, estimatedFee, b.EstimateGasFee(0))
+
+		estimatedFee = std.NewCoin("ugnot", 100)
+		assert.Equal(t, estimatedFee, b.EstimateGasFee(0))
+	})
+
+	t.Run("accepted fee scaled to gas wanted", func(t *testing.T) {
+		t.Parallel()
+
+		b := newFeeBumper(
+			&mockEstimator{
+				estimateGasFeeFn: func(gasWanted int64) std.Coin {
+					// 1ugnot / 1000 gas
+					return std.NewCoin("ugnot", gasWanted/1000)
+				},
+			},
+			1.5,
+			std.NewCoin("ugnot", 1000),
+			time.Hour,
+		)
+
+		b.accept(std.NewCoin("ugnot", 150), 100000)
+
+		// Make sure the accepted fee ratio (1.5)
+		// applies to transactions with more gas wanted
+		assert.Equal(t, std.NewCoin("ugnot", 300), b.EstimateGasFee(200000))
+
+		// Make sure the scaled fee is within the max gas fee
+		assert.Equal(t, std.NewCoin("ugnot", 1000), b.EstimateGasFee(1000000))
+	})
+
+	t.Run("accepted fee expired", func(t *testing.T) {
+		t.Parallel()
+
+		b := newBumper(std.NewCoin("ugnot", 100))
+
+		b.accept(std.NewCoin("ugnot", 150), 100000)
+		require.Equal(t, std.NewCoin("ugnot", 150), b.EstimateGasFee(0))
+
+		// Expire the accepted fee
+		b.minFee.expiresAt = time.Now().Add(-time.Second)
+
+		assert.Equal(t, std.NewCoin("ugnot", 100), b.EstimateGasFee(0))
+		assert.Nil(t, b.minFee)
+	})
+}
+
+func TestFaucet_FeeBump(t *testing.T) {
+	t.Parallel()
+
+	// newBumpingFaucet creates a faucet with fee bumping enabled, and the
+	// given account balance, where the network requires the given min fee
+	newBumpingFaucet := func(t *testing.T, minFee, balance int64) (*Faucet, *[]std.Coin) {
+		t.Helper()
+
+		var (
+			broadcastFees []std.Coin
+
+			mockEstimator = &mockEstimator{
+				estimateGasFeeFn: func(_ int64) std.Coin {
+					return std.NewCoin("ugnot", 100)
+				},
+			}
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
+					return std.NewBaseAccount(
+						address,
+						std.NewCoins(std.NewCoin("ugnot", balance)),
+						nil,
+						0,
+						0,
+					), nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					broadcastFees = append(broadcastFees, tx.Fee.GasFee)
+
+					// Make sure the transaction is re-signed
+					if len(tx.Signatures) != 1 {
+						return nil, assert.AnError
+					}
+
+					if tx.Fee.GasFee.Amount < minFee {
+						return &coreTypes.ResultBroadcastTxCommit{
+							CheckTx: abci.ResponseCheckTx{
+								ResponseBase: abci.ResponseBase{
+									Error: std.InsufficientFeeError{},
+								},
+							},
+						}, nil
+					}
+
+					return &coreTypes.ResultBroadcastTxCommit{}, nil
+				},
+			}
+		)
+
+		cfg := config.DefaultConfig()
+		cfg.FeeBumpFactor = 1.5
+		cfg.MaxGasFee = "300ugnot"
+
+		return newTestFaucet(t, cfg, mockClient, mockEstimator), &broadcastFees
+	}
+
+	t.Run("fee bumped until accepted", func(t *testing.T) {
+		t.Parallel()
+
+		f, broadcastFees := newBumpingFaucet(t, 200, 1000000)
+
+		result, err := f.transferFunds(
+			context.Background(),
+			crypto.Address{2},
+			std.NewCoins(std.NewCoin("ugnot", 10)),
+		)
+		require.NoError(t, err)
+
+		// 100 -> 150 -> 225
+		assert.Equal(t, []std.Coin{
+			std.NewCoin("ugnot", 100),
+			std.NewCoin("ugnot", 150),
+			std.NewCoin("ugnot", 225),
+		}, *broadcastFees)
+
+		assert.Equal(t, std.NewCoin("ugnot", 225), result.fee)
+
+		// Make sure the accepted fee is used for the next drip
+		*broadcastFees = nil
+
+		_, err = f.transferFunds(
+			context.Background(),
+			crypto.Address{2},
+			std.NewCoins(std.NewCoin("ugnot", 10)),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []std.Coin{std.NewCoin("ugnot", 225)}, *broadcastFees)
+	})
+
+	t.Run("fee cap reached", func(t *testing.T) {
+		t.Parallel()
+
+		f, broadcastFees := newBumpingFaucet(t, 1000, 1000000)
+
+		_, err := f.transferFunds(
+			context.Background(),
+			crypto.Address{2},
+			std.NewCoins(std.NewCoin("ugnot", 10)),
+		)
+		require.ErrorIs(t, err, std.InsufficientFeeError{})
+
+		// 100 -> 150 -> 225 -> 300 (cap)
+		assert.Equal(t, []std.Coin{
+			std.NewCoin("ugnot", 100),
+			std.NewCoin("ugnot", 150),
+			std.NewCoin("ugnot", 225),
+			std.NewCoin("ugnot", 300),
+		}, *broadcastFees)
+	})
+	t.Run("bumped fee not covered", func(t *testing.T) {
+		t.Parallel()
+
+		// The balance covers the drip with
+		// the estimated fee, but not the bumped fee
+		f, broadcastFees := newBumpingFaucet(t, 200, 10+150-1)
+
+		_, err := f.transferFunds(
+			context.Background(),
+			crypto.Address{2},
+			std.NewCoins(std.NewCoin("ugnot", 10)),
+		)
+		require.ErrorIs(t, err, std.InsufficientFeeError{})
+
+		// Make sure the bumped fee is never broadcast
+		assert.Equal(t, []std.Coin{std.NewCoin("ugnot", 100)}, *broadcastFees)
+	})
+}
@@ -2,33 +2,39 @@ package faucet
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"regexp"
+	"sync"
 	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/go-chi/render"
 
+	"github.com/gnolang/faucet/policy"
 	"github.com/gnolang/faucet/spec"
 )
 
-const faucetSuccess = "successfully executed faucet transfer"
-
-const DefaultDripMethod = "drip" // the default JSON-RPC method for a faucet drip
+const (
+	DefaultDripMethod = "drip"        // the default JSON-RPC method for a faucet drip
+	DripStatusMethod  = "drip_status" // the JSON-RPC method for a faucet drip status
+)
 
 var (
 	errInvalidBeneficiary = errors.New("invalid beneficiary address")
 	errInvalidSendAmount  = errors.New("invalid send amount")
 	errInvalidMethod      = errors.New("unknown RPC method call")
+	errInvalidTxHash      = errors.New("invalid transaction hash")
 )
 
-// wrapJSONRPC wraps the given handler and middlewares into a JSON-RPC 2.0 pipeline
-func wrapJSONRPC(handlerFn HandlerFunc, mws ...Middleware) http.HandlerFunc {
+// wrapJSONRPC wraps the given handler and middlewares into a JSON-RPC 2.0 pipeline.
+// The requests of a batch are handled sequentially, unless maxConcurrent is
+// greater than 1, in which case up to maxConcurrent requests are handled at once
+func wrapJSONRPC(handlerFn HandlerFunc, maxConcurrent int, mws ...Middleware) http.HandlerFunc {
 	callChain := chainMiddlewares(mws...)(handlerFn)
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -47,27 +53,55 @@ func wrapJSONRPC(handlerFn HandlerFunc, mws ...Middleware) http.HandlerFunc {
 		var (
 			ctx = r.Context()
 
-			responses = make(spec.BaseJSONResponses, 0)
+			responses = make(spec.BaseJSONResponses, len(requests))
 		)
 
-		for _, req := range requests {
+		handleRequest := func(i int, req *spec.BaseJSONRequest) {
 			// Make sure it's a valid base request
 			if !spec.IsValidBaseRequest(req) {
-				responses = append(responses, spec.NewJSONResponse(
+				responses[i] = spec.NewJSONResponse(
 					req.ID,
 					nil,
 					spec.NewJSONError("invalid JSON-RPC 2.0 request", spec.InvalidRequestErrorCode),
-				))
+				)
 
-				continue
+				return
 			}
 
 			// Parse the request.
 			// This executes all the middlewares, and
 			// finally the base handler for the endpoint
-			resp := callChain(ctx, req)
+			responses[i] = callChain(ctx, req)
+		}
+
+		if maxConcurrent <= 1 {
+			for i, req := range requests {
+				handleRequest(i, req)
+			}
+		} else {
+			// Handle the requests concurrently, so the
+			// drips within a batch can be aggregated
+			var (
+				wg  sync.WaitGroup
+				sem = make(chan struct{}, maxConcurrent)
+			)
+
+			for i, req := range requests {
+				sem <- struct{}{}
+
+				wg.Add(1)
 
-			responses = append(responses, resp)
+				go func() {
+					defer func() {
+						<-sem
+						wg.Done()
+					}()
+
+					handleRequest(i, req)
+				}()
+			}
+
+			wg.Wait()
 		}
 
 		w.Header().Set("Content-Type", JSONMimeType)
@@ -123,19 +157,24 @@ type drip struct {
 	to     crypto.Address
 }
 
-var amountRegex = regexp.MustCompile(`^\d+ugnot$`)
-
 // defaultHTTPHandler is the default faucet transfer handler
-func (f *Faucet) defaultHTTPHandler(_ context.Context, req *spec.BaseJSONRequest) *spec.BaseJSONResponse {
-	// Make sure the method call on "/" is "drip"
-	if req.Method != DefaultDripMethod {
+func (f *Faucet) defaultHTTPHandler(ctx context.Context, req *spec.BaseJSONRequest) *spec.BaseJSONResponse {
+	switch req.Method {
+	case DefaultDripMethod:
+		return f.handleDrip(ctx, req)
+	case DripStatusMethod:
+		return f.handleDripStatus(ctx, req)
+	default:
 		return spec.NewJSONResponse(
 			req.ID,
 			nil,
 			spec.NewJSONError(errInvalidMethod.Error(), spec.MethodNotFoundErrorCode),
 		)
 	}
+}
 
+// handleDrip handles the faucet drip request
+func (f *Faucet) handleDrip(ctx context.Context, req *spec.BaseJSONRequest) *spec.BaseJSONResponse {
 	// Parse params into a drip request
 	dripRequest, err := extractDripRequest(req.Params)
 	if err != nil {
@@ -153,24 +192,108 @@ func (f *Faucet) defaultHTTPHandler(_ context.Context, req *spec.BaseJSONRequest
 		dripRequest.amount = f.maxSendAmount
 	}
 
-	// Check if the amount exceeds the max
-	// drip amount for the faucet
-	if dripRequest.amount.IsAllGT(f.maxSendAmount) {
+	// Check if the amount is within the
+	// faucet limits, for each denomination
+	if err := f.amountPolicy.ValidateDrip(dripRequest.amount); err != nil {
 		return spec.NewJSONResponse(
 			req.ID,
 			nil,
-			spec.NewJSONError(errInvalidSendAmount.Error(), spec.InvalidRequestErrorCode),
+			newAmountError(err),
 		)
 	}
 
 	// Attempt fund transfer
-	if err := f.transferFunds(dripRequest.to, dripRequest.amount); err != nil {
+	result, err := f.transferFunds(
+		withDripInfo(ctx, req.ID),
+		dripRequest.to,
+		dripRequest.amount,
+	)
+	if err != nil {
 		f.logger.Debug("unable to handle drip", "req", req, "err", err)
 
+		if errors.Is(err, errQueueFull) {
+			return spec.NewJSONResponse(
+				req.ID,
+				nil,
+				spec.NewJSONError(err.Error(), spec.ServerBusyErrorCode),
+			)
+		}
+
+		return spec.NewJSONResponse(req.ID, nil, newTransferError(err))
+	}
+
+	return spec.NewJSONResponse(req.ID, newDripResult(result, dripRequest.amount), nil)
+}
+
+// newAmountError creates a JSON-RPC error for the invalid drip amount,
+// with the violated denomination limit as the error data
+func newAmountError(err error) *spec.BaseJSONError {
+	jsonErr := spec.NewJSONError(
+		fmt.Sprintf("%s: %s", errInvalidSendAmount.Error(), err.Error()),
+		spec.InvalidRequestErrorCode,
+	)
+
+	var amountErr *policy.AmountError
+	if errors.As(err, &amountErr) {
+		jsonErr.Data = amountErr
+	}
+
+	return jsonErr
+}
+
+// newTransferError creates a JSON-RPC error for the failed drip transfer,
+// with the simulation details as the error data, if any
+func newTransferError(err error) *spec.BaseJSONError {
+	jsonErr := spec.GenerateResponseError(err)
+
+	var simulationErr *SimulationError
+	if errors.As(err, &simulationErr) {
+		jsonErr.Data = simulationErr
+	}
+
+	return jsonErr
+}
+
+// handleDripStatus handles the faucet drip status request
+func (f *Faucet) handleDripStatus(ctx context.Context, req *spec.BaseJSONRequest) *spec.BaseJSONResponse {
+	// Parse params into a transaction hash
+	hash, err := extractTxHash(req.Params)
+	if err != nil {
+		return spec.NewJSONResponse(
+			req.ID,
+			nil,
+			spec.NewJSONError(err.Error(), spec.InvalidParamsErrorCode),
+		)
+	}
+
+	// Fetch the drip status
+	status, err := f.getDripStatus(ctx, hash)
+	if err != nil {
+		f.logger.Debug("unable to fetch drip status", "req", req, "err", err)
+
 		return spec.NewJSONResponse(req.ID, nil, spec.GenerateResponseError(err))
 	}
 
-	return spec.NewJSONResponse(req.ID, faucetSuccess, nil)
+	return spec.NewJSONResponse(req.ID, status, nil)
+}
+
+// extractTxHash extracts the base64 transaction hash from the request
+func extractTxHash(params []any) ([]byte, error) {
+	if len(params) < 1 {
+		return nil, errInvalidTxHash
+	}
+
+	hashStr, ok := params[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: hash must be a string", errInvalidTxHash)
+	}
+
+	hash, err := base64.StdEncoding.DecodeString(hashStr)
+	if err != nil || len(hash) == 0 {
+		return nil, fmt.Errorf("%w: hash must be base64 encoded", errInvalidTxHash)
+	}
+
+	return hash, nil
 }
 
 // extractDripRequest extracts the base drip params from the request
@@ -201,7 +324,7 @@ func extractDripRequest(params []any) (*drip, error) {
 	}
 
 	amountStr, ok := params[1].(string)
-	if !ok || !amountRegex.MatchString(amountStr) {
+	if !ok {
 		return nil, errInvalidSendAmount
 	}
 
@@ -217,14 +340,30 @@ func extractDripRequest(params []any) (*drip, error) {
 }
 
 // healthcheckHandler is the default health check handler for the faucet
-func (f *Faucet) healthcheckHandler(_ http.ResponseWriter, r *http.Request) {
+func (f *Faucet) healthcheckHandler(w http.ResponseWriter, r *http.Request) {
+	// Report the treasury exhaustion, if any
+	if f.rebalancer != nil {
+		if exhausted, balance := f.rebalancer.status(); exhausted {
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, &response{
+				Message: "treasury account exhausted",
+				Info: map[string]any{
+					"balance": balance.String(),
+					"time":    time.Now().String(),
+				},
+			})
+
+			return
+		}
+	}
+
 	render.Status(r, http.StatusOK)
 }
 
 // readycheckHandler is the default ready check handler for the faucet
 func (f *Faucet) readycheckHandler(w http.ResponseWriter, r *http.Request) {
 	// Grab the node's status
-	status, err := f.client.Status()
+	status, err := f.client.Status(r.Context())
 	if err != nil {
 		render.JSON(w, r, &response{
 			Message: fmt.Sprintf("node not ready: %s", err.Error()),
@@ -238,12 +377,21 @@ func (f *Faucet) readycheckHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	info := map[string]any{
+		"height": status.SyncInfo.LatestBlockHeight,
+		"time":   time.Now().String(),
+	}
+
+	// Report the treasury state, if any
+	if f.rebalancer != nil {
+		exhausted, _ := f.rebalancer.status()
+
+		info["treasuryExhausted"] = exhausted
+	}
+
 	render.JSON(w, r, &response{
 		Message: "node is ready",
-		Info: map[string]any{
-			"height": status.SyncInfo.LatestBlockHeight,
-			"time":   time.Now().String(),
-		},
+		Info:    info,
 	})
 }
 
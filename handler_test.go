@@ -9,11 +9,15 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gnolang/faucet/config"
 	"github.com/gnolang/faucet/estimate/static"
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/faucet/policy"
 	"github.com/gnolang/faucet/spec"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
@@ -36,6 +40,20 @@ func decodeResponse[T spec.BaseJSONResponse | spec.BaseJSONResponses](t *testing
 	return response
 }
 
+// decodeDripResult decodes the drip result from the JSON response result
+func decodeDripResult(t *testing.T, result any) *DripResult {
+	t.Helper()
+
+	encodedResult, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var dripResult *DripResult
+
+	require.NoError(t, json.Unmarshal(encodedResult, &dripResult))
+
+	return dripResult
+}
+
 // getFreePort fetches a currently free port on the OS
 func getFreePort(t *testing.T) int {
 	t.Helper()
@@ -111,6 +129,15 @@ func TestFaucet_Serve_ValidRequests(t *testing.T) {
 		return fmt.Sprintf("http://%s", address)
 	}
 
+	expectedResult := &DripResult{
+		Status:    DripStatusCommitted,
+		Hash:      "",
+		From:      crypto.Address{1}.String(),
+		Amount:    sendAmount.String(),
+		Fee:       gasFee.String(),
+		GasWanted: 100000,
+	}
+
 	testTable := []struct {
 		requestValidateFn func(response []byte)
 		name              string
@@ -122,7 +149,7 @@ func TestFaucet_Serve_ValidRequests(t *testing.T) {
 				response := decodeResponse[spec.BaseJSONResponse](t, resp)
 
 				assert.Empty(t, response.Error)
-				assert.Equal(t, faucetSuccess, response.Result)
+				assert.Equal(t, expectedResult, decodeDripResult(t, response.Result))
 			},
 			"single request",
 			encodedSingleValidRequest,
@@ -135,7 +162,7 @@ func TestFaucet_Serve_ValidRequests(t *testing.T) {
 
 				for _, response := range *responses {
 					assert.Empty(t, response.Error)
-					assert.Equal(t, faucetSuccess, response.Result)
+					assert.Equal(t, expectedResult, decodeDripResult(t, response.Result))
 				}
 			},
 			"bulk request",
@@ -174,7 +201,7 @@ func TestFaucet_Serve_ValidRequests(t *testing.T) {
 						return fundAccount
 					},
 				}
-				mockPrivKey = &mockPrivKey{
+				mockSigner = &mockSigner{
 					signFn: func(_ []byte) ([]byte, error) {
 						return signature, nil
 					},
@@ -188,9 +215,9 @@ func TestFaucet_Serve_ValidRequests(t *testing.T) {
 							fundAccount,
 						}
 					},
-					getKeyFn: func(address crypto.Address) crypto.PrivKey {
+					getSignerFn: func(address crypto.Address) keyring.Signer {
 						if address == fundAccount {
-							return mockPrivKey
+							return mockSigner
 						}
 
 						return nil
@@ -205,14 +232,14 @@ func TestFaucet_Serve_ValidRequests(t *testing.T) {
 					},
 				}
 				mockClient = &mockClient{
-					getAccountFn: func(address crypto.Address) (std.Account, error) {
+					getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
 						if address == fundAccount {
 							return mockAccount, nil
 						}
 
 						return nil, errors.New("account not found")
 					},
-					sendTransactionCommitFn: func(tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
 						capturedTxs = append(capturedTxs, tx)
 
 						return broadcastResponse, nil
@@ -228,14 +255,12 @@ func TestFaucet_Serve_ValidRequests(t *testing.T) {
 				static.New(gasFee, 100000),
 				mockClient,
 				WithConfig(cfg),
+				WithKeyring(mockKeyring),
 			)
 
 			require.NoError(t, err)
 			require.NotNil(t, f)
 
-			// Update the keyring
-			f.keyring = mockKeyring
-
 			// Start the faucet
 			ctx, cancelFn := context.WithCancel(context.Background())
 			defer cancelFn()
@@ -318,7 +343,7 @@ func TestFaucet_Serve_Middleware(t *testing.T) {
 				return fundAccount
 			},
 		}
-		mockPrivKey = &mockPrivKey{
+		mockSigner = &mockSigner{
 			signFn: func(_ []byte) ([]byte, error) {
 				return []byte("signature"), nil
 			},
@@ -330,9 +355,9 @@ func TestFaucet_Serve_Middleware(t *testing.T) {
 			getAddressesFn: func() []crypto.Address {
 				return []crypto.Address{fundAccount}
 			},
-			getKeyFn: func(address crypto.Address) crypto.PrivKey {
+			getSignerFn: func(address crypto.Address) keyring.Signer {
 				if address == fundAccount {
-					return mockPrivKey
+					return mockSigner
 				}
 
 				return nil
@@ -343,14 +368,14 @@ func TestFaucet_Serve_Middleware(t *testing.T) {
 			getCoinsFn:   func() std.Coins { return requiredFunds },
 		}
 		mockClient = &mockClient{
-			getAccountFn: func(address crypto.Address) (std.Account, error) {
+			getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
 				if address == fundAccount {
 					return mockAccount, nil
 				}
 
 				return nil, errors.New("account not found")
 			},
-			sendTransactionCommitFn: func(_ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+			sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
 				return &coreTypes.ResultBroadcastTxCommit{
 					CheckTx: abci.ResponseCheckTx{
 						ResponseBase: abci.ResponseBase{Error: nil},
@@ -398,11 +423,10 @@ func TestFaucet_Serve_Middleware(t *testing.T) {
 			mockClient,
 			WithConfig(cfg),
 			WithMiddlewares([]Middleware{idMW, addrMW}),
+			WithKeyring(mockKeyring),
 		)
 		require.NoError(t, err)
 
-		f.keyring = mockKeyring
-
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
@@ -422,7 +446,7 @@ func TestFaucet_Serve_Middleware(t *testing.T) {
 		r := decodeResponse[spec.BaseJSONResponse](t, body)
 
 		assert.Empty(t, r.Error)
-		assert.Equal(t, faucetSuccess, r.Result)
+		assert.Equal(t, DripStatusCommitted, decodeDripResult(t, r.Result).Status)
 		assert.Equal(t, 2, executed)
 
 		cancel()
@@ -463,11 +487,10 @@ func TestFaucet_Serve_Middleware(t *testing.T) {
 			mockClient,
 			WithConfig(cfg),
 			WithMiddlewares([]Middleware{failMW, idMW}), // first mw should fail
+			WithKeyring(mockKeyring),
 		)
 		require.NoError(t, err)
 
-		f.keyring = mockKeyring
-
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
@@ -594,7 +617,7 @@ func TestFaucet_Serve_InvalidRequests(t *testing.T) {
 						return fundAccount
 					},
 				}
-				mockPrivKey = &mockPrivKey{
+				mockSigner = &mockSigner{
 					signFn: func(_ []byte) ([]byte, error) {
 						return signature, nil
 					},
@@ -608,9 +631,9 @@ func TestFaucet_Serve_InvalidRequests(t *testing.T) {
 							fundAccount,
 						}
 					},
-					getKeyFn: func(address crypto.Address) crypto.PrivKey {
+					getSignerFn: func(address crypto.Address) keyring.Signer {
 						if address == fundAccount {
-							return mockPrivKey
+							return mockSigner
 						}
 
 						return nil
@@ -625,14 +648,14 @@ func TestFaucet_Serve_InvalidRequests(t *testing.T) {
 					},
 				}
 				mockClient = &mockClient{
-					getAccountFn: func(address crypto.Address) (std.Account, error) {
+					getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
 						if address == fundAccount {
 							return mockAccount, nil
 						}
 
 						return nil, errors.New("account not found")
 					},
-					sendTransactionCommitFn: func(tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
 						capturedTxs = append(capturedTxs, tx)
 
 						return broadcastResponse, nil
@@ -648,14 +671,12 @@ func TestFaucet_Serve_InvalidRequests(t *testing.T) {
 				static.New(gasFee, 100000),
 				mockClient,
 				WithConfig(cfg),
+				WithKeyring(mockKeyring),
 			)
 
 			require.NoError(t, err)
 			require.NotNil(t, f)
 
-			// Update the keyring
-			f.keyring = mockKeyring
-
 			// Start the faucet
 			ctx, cancelFn := context.WithCancel(context.Background())
 			defer cancelFn()
@@ -812,7 +833,7 @@ func TestFaucet_Serve_NoFundedAccounts(t *testing.T) {
 				return fundAccount
 			},
 		}
-		mockPrivKey = &mockPrivKey{
+		mockSigner = &mockSigner{
 			signFn: func(_ []byte) ([]byte, error) {
 				return signature, nil
 			},
@@ -826,9 +847,9 @@ func TestFaucet_Serve_NoFundedAccounts(t *testing.T) {
 					fundAccount,
 				}
 			},
-			getKeyFn: func(address crypto.Address) crypto.PrivKey {
+			getSignerFn: func(address crypto.Address) keyring.Signer {
 				if address == fundAccount {
-					return mockPrivKey
+					return mockSigner
 				}
 
 				return nil
@@ -843,14 +864,14 @@ func TestFaucet_Serve_NoFundedAccounts(t *testing.T) {
 			},
 		}
 		mockClient = &mockClient{
-			getAccountFn: func(address crypto.Address) (std.Account, error) {
+			getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
 				if address == fundAccount {
 					return mockAccount, nil
 				}
 
 				return nil, errors.New("account not found")
 			},
-			sendTransactionCommitFn: func(tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+			sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
 				capturedTxs = append(capturedTxs, tx)
 
 				return broadcastResponse, nil
@@ -866,14 +887,12 @@ func TestFaucet_Serve_NoFundedAccounts(t *testing.T) {
 		static.New(gasFee, 100000),
 		mockClient,
 		WithConfig(cfg),
+		WithKeyring(mockKeyring),
 	)
 
 	require.NoError(t, err)
 	require.NotNil(t, f)
 
-	// Update the keyring
-	f.keyring = mockKeyring
-
 	// Start the faucet
 	ctx, cancelFn := context.WithCancel(context.Background())
 	defer cancelFn()
@@ -932,6 +951,10 @@ func TestFaucet_Serve_InvalidSendAmount(t *testing.T) {
 			"excessive send amount",
 			maxSendAmount.Add(std.MustParseCoins("100ugnot")),
 		},
+		{
+			"unsupported denom alongside faucet denom",
+			maxSendAmount.Add(std.MustParseCoins("10atom")),
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -1011,3 +1034,203 @@ func TestFaucet_Serve_InvalidSendAmount(t *testing.T) {
 		})
 	}
 }
+
+func TestFaucet_Drip_MultiDenom(t *testing.T) {
+	t.Parallel()
+
+	var (
+		maxSendAmount = std.MustParseCoins("1000ugnot,500utest")
+
+		validAddress = crypto.MustAddressFromString("g155n659f89cfak0zgy575yqma64sm4tv6exqk99")
+	)
+
+	// newMultiDenomFaucet creates a faucet that hands out
+	// multiple denominations, and captures the broadcast txs
+	newMultiDenomFaucet := func(t *testing.T) (*Faucet, *[]*std.Tx) {
+		t.Helper()
+
+		var (
+			capturedTxs []*std.Tx
+
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, _ crypto.Address) (std.Account, error) {
+					return &mockAccount{
+						getCoinsFn: func() std.Coins {
+							return std.MustParseCoins("10000ugnot,10000utest")
+						},
+					}, nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					capturedTxs = append(capturedTxs, tx)
+
+					return &coreTypes.ResultBroadcastTxCommit{}, nil
+				},
+			}
+		)
+
+		cfg := config.DefaultConfig()
+		cfg.MaxSendAmount = maxSendAmount.String()
+
+		f := newTestFaucet(
+			t,
+			cfg,
+			mockClient,
+			static.New(std.MustParseCoin("1ugnot"), 100000),
+			crypto.Address{0},
+		)
+
+		return f, &capturedTxs
+	}
+
+	testTable := []struct {
+		name           string
+		params         []any
+		expectedAmount std.Coins
+	}{
+		{
+			"all denoms by default",
+			[]any{validAddress.String()},
+			maxSendAmount,
+		},
+		{
+			"single denom",
+			[]any{validAddress.String(), "100utest"},
+			std.MustParseCoins("100utest"),
+		},
+		{
+			"multiple denoms",
+			[]any{validAddress.String(), "100ugnot,500utest"},
+			std.MustParseCoins("100ugnot,500utest"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, capturedTxs := newMultiDenomFaucet(t)
+
+			response := f.defaultHTTPHandler(
+				context.Background(),
+				spec.NewJSONRequest(0, DefaultDripMethod, testCase.params),
+			)
+			require.Nil(t, response.Error)
+
+			// Make sure the requested denoms were sent
+			require.Len(t, *capturedTxs, 1)
+			require.Len(t, (*capturedTxs)[0].Msgs, 1)
+
+			msg, ok := (*capturedTxs)[0].Msgs[0].(bank.MsgSend)
+			require.True(t, ok)
+
+			assert.Equal(t, testCase.expectedAmount, msg.Amount)
+		})
+	}
+
+	t.Run("denom over its max amount", func(t *testing.T) {
+		t.Parallel()
+
+		f, capturedTxs := newMultiDenomFaucet(t)
+
+		// ugnot is within its max, but utest isn't
+		response := f.defaultHTTPHandler(
+			context.Background(),
+			spec.NewJSONRequest(0, DefaultDripMethod, []any{validAddress.String(), "100ugnot,501utest"}),
+		)
+		require.NotNil(t, response.Error)
+
+		assert.Contains(t, response.Error.Message, errInvalidSendAmount.Error())
+		assert.Empty(t, *capturedTxs)
+
+		// Make sure the violated limit is reported
+		var amountErr *policy.AmountError
+		require.ErrorAs(t, response.Error.Data.(error), &amountErr)
+
+		assert.ErrorIs(t, amountErr, policy.ErrAmountAboveMax)
+		assert.Equal(t, "utest", amountErr.Denom)
+		assert.Equal(t, int64(501), amountErr.Amount)
+		assert.Equal(t, int64(500), amountErr.Limit)
+	})
+}
+
+func TestWrapJSONRPC_BatchConcurrency(t *testing.T) {
+	t.Parallel()
+
+	// newBatchRequest creates a JSON-RPC batch request with n requests
+	newBatchRequest := func(t *testing.T, n int) *http.Request {
+		t.Helper()
+
+		requests := make(spec.BaseJSONRequests, 0, n)
+
+		for i := range n {
+			requests = append(requests, spec.NewJSONRequest(uint(i), "drip", nil))
+		}
+
+		body, err := json.Marshal(requests)
+		require.NoError(t, err)
+
+		return httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	}
+
+	testTable := []struct {
+		name          string
+		maxConcurrent int
+		expectedMax   int64
+	}{
+		{
+			"batching disabled, sequential",
+			1,
+			1,
+		},
+		{
+			"batching enabled, bounded",
+			3,
+			3,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				inflight, maxInflight atomic.Int64
+
+				numRequests = 10
+			)
+
+			handlerFn := func(_ context.Context, req *spec.BaseJSONRequest) *spec.BaseJSONResponse {
+				current := inflight.Add(1)
+				defer inflight.Add(-1)
+
+				for {
+					seen := maxInflight.Load()
+					if current <= seen || maxInflight.CompareAndSwap(seen, current) {
+						break
+					}
+				}
+
+				time.Sleep(10 * time.Millisecond)
+
+				return spec.NewJSONResponse(req.ID, "ok", nil)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			wrapJSONRPC(handlerFn, testCase.maxConcurrent)(
+				recorder,
+				newBatchRequest(t, numRequests),
+			)
+
+			responses := decodeResponse[spec.BaseJSONResponses](t, recorder.Body.Bytes())
+			require.Len(t, *responses, numRequests)
+
+			// Make sure the requests were handled within the bound
+			assert.LessOrEqual(t, maxInflight.Load(), testCase.expectedMax)
+
+			if testCase.expectedMax == 1 {
+				assert.Equal(t, int64(1), maxInflight.Load())
+			}
+		})
+	}
+}
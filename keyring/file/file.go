@@ -0,0 +1,69 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/faucet/keyring/memory"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+)
+
+var errNoKeys = errors.New("no local keys in the keybase")
+
+// New loads the keys from the encrypted on-disk keybase at the given
+// (gnokey home) directory, decrypting them once with the passphrase.
+// If no key names (or addresses) are specified, all local keys in the
+// keybase are loaded. The loaded keys are served from memory
+func New(dir, passphrase string, names ...string) (*memory.Keyring, error) {
+	kb, err := keys.NewKeyBaseFromDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open keybase, %w", err)
+	}
+
+	defer kb.CloseDB()
+
+	if len(names) == 0 {
+		if names, err = localKeyNames(kb); err != nil {
+			return nil, err
+		}
+	}
+
+	privKeys := make([]crypto.PrivKey, 0, len(names))
+
+	for _, name := range names {
+		key, err := kb.ExportPrivKey(name, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load key %q, %w", name, err)
+		}
+
+		privKeys = append(privKeys, key)
+	}
+
+	return memory.NewFromKeys(privKeys...), nil
+}
+
+// localKeyNames returns the names of the local keys in the keybase.
+// Ledger, offline and multisig keys can't sign for the faucet
+func localKeyNames(kb keys.Keybase) ([]string, error) {
+	infos, err := kb.List()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list keys, %w", err)
+	}
+
+	names := make([]string, 0, len(infos))
+
+	for _, info := range infos {
+		if info.GetType() != keys.TypeLocal {
+			continue
+		}
+
+		names = append(names, info.GetName())
+	}
+
+	if len(names) == 0 {
+		return nil, errNoKeys
+	}
+
+	return names, nil
+}
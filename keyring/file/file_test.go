@@ -0,0 +1,144 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/bip39"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPassphrase = "passphrase"
+
+// generateTestMnemonic generates a new test BIP39 mnemonic
+func generateTestMnemonic(t *testing.T) string {
+	t.Helper()
+
+	entropySeed, err := bip39.NewEntropy(256)
+	require.NoError(t, err)
+
+	mnemonic, err := bip39.NewMnemonic(entropySeed)
+	require.NoError(t, err)
+
+	return mnemonic
+}
+
+// newTestKeybase creates a keybase in a temporary directory,
+// with the given number of encrypted local keys
+func newTestKeybase(t *testing.T, numKeys int) (string, []crypto.Address) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	kb, err := keys.NewKeyBaseFromDir(dir)
+	require.NoError(t, err)
+
+	addresses := make([]crypto.Address, 0, numKeys)
+
+	for i := range numKeys {
+		info, err := kb.CreateAccount(
+			string(rune('a'+i)),
+			generateTestMnemonic(t),
+			"",
+			testPassphrase,
+			0,
+			0,
+		)
+		require.NoError(t, err)
+
+		addresses = append(addresses, info.GetAddress())
+	}
+
+	return dir, addresses
+}
+
+func TestKeyring_New(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all local keys loaded", func(t *testing.T) {
+		t.Parallel()
+
+		dir, addresses := newTestKeybase(t, 3)
+
+		kr, err := New(dir, testPassphrase)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, addresses, kr.GetAddresses())
+
+		for _, address := range addresses {
+			signer := kr.GetSigner(address)
+			require.NotNil(t, signer)
+
+			assert.Equal(t, address, signer.PubKey().Address())
+		}
+	})
+
+	t.Run("named keys loaded", func(t *testing.T) {
+		t.Parallel()
+
+		dir, addresses := newTestKeybase(t, 3)
+
+		kr, err := New(dir, testPassphrase, "b", addresses[2].String())
+		require.NoError(t, err)
+
+		assert.Equal(t, addresses[1:], kr.GetAddresses())
+		assert.Nil(t, kr.GetSigner(addresses[0]))
+	})
+
+	t.Run("duplicate keys loaded once", func(t *testing.T) {
+		t.Parallel()
+
+		dir, addresses := newTestKeybase(t, 2)
+
+		// The same key, by name and by address
+		kr, err := New(dir, testPassphrase, "a", addresses[0].String(), "b")
+		require.NoError(t, err)
+
+		assert.Equal(t, addresses, kr.GetAddresses())
+	})
+
+	t.Run("offline keys skipped", func(t *testing.T) {
+		t.Parallel()
+
+		dir, addresses := newTestKeybase(t, 1)
+
+		kb, err := keys.NewKeyBaseFromDir(dir)
+		require.NoError(t, err)
+
+		_, err = kb.CreateOffline("offline", secp256k1.GenPrivKey().PubKey())
+		require.NoError(t, err)
+
+		kr, err := New(dir, testPassphrase)
+		require.NoError(t, err)
+
+		assert.Equal(t, addresses, kr.GetAddresses())
+	})
+
+	t.Run("invalid passphrase", func(t *testing.T) {
+		t.Parallel()
+
+		dir, _ := newTestKeybase(t, 1)
+
+		_, err := New(dir, "invalid")
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		t.Parallel()
+
+		dir, _ := newTestKeybase(t, 1)
+
+		_, err := New(dir, testPassphrase, "unknown")
+		assert.Error(t, err)
+	})
+
+	t.Run("empty keybase", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(t.TempDir(), testPassphrase)
+		assert.ErrorIs(t, err, errNoKeys)
+	})
+}
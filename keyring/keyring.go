@@ -1,12 +1,50 @@
 package keyring
 
-import "github.com/gnolang/gno/tm2/pkg/crypto"
+import (
+	"context"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
 
 // Keyring defines the faucet keyring functionality
 type Keyring interface {
 	// GetAddresses fetches the addresses in the keyring
 	GetAddresses() []crypto.Address
 
-	// GetKey fetches the private key associated with the specified address
-	GetKey(address crypto.Address) crypto.PrivKey
+	// GetSigner fetches the signer associated with the specified address
+	GetSigner(address crypto.Address) Signer
+}
+
+// Signer defines the signing functionality of a faucet account.
+// The signer doesn't need to expose the private key (it can sign remotely).
+// In-process private keys are signers through NewKeySigner
+type Signer interface {
+	// PubKey returns the public key of the signer
+	PubKey() crypto.PubKey
+
+	// Sign signs the given payload, within the context
+	Sign(ctx context.Context, msg []byte) ([]byte, error)
+}
+
+// keySigner is the signer of an in-process private key
+type keySigner struct {
+	key crypto.PrivKey
+}
+
+// NewKeySigner creates a signer for the in-process private key
+func NewKeySigner(key crypto.PrivKey) Signer {
+	return &keySigner{
+		key: key,
+	}
+}
+
+// PubKey returns the public key of the private key
+func (s *keySigner) PubKey() crypto.PubKey {
+	return s.key.PubKey()
+}
+
+// Sign signs the given payload with the private key.
+// Signing is local, so it doesn't depend on the context
+func (s *keySigner) Sign(_ context.Context, msg []byte) ([]byte, error) {
+	return s.key.Sign(msg)
 }
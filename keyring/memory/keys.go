@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/armor"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+)
+
+const (
+	armorBegin = "-----BEGIN"
+	armorEnd   = "-----END"
+)
+
+var (
+	errNoKeys             = errors.New("no private keys in the file")
+	errUnterminatedArmor  = errors.New("unterminated armored private key")
+	errInvalidPrivKeySize = errors.New("invalid private key size")
+)
+
+// ReadKeys reads the private keys from the given file.
+// The file lists hex-encoded private keys (one per line), and armored
+// private keys (as exported by gnokey), which are decrypted with the
+// passphrase if encrypted. Empty lines and lines starting with # are ignored
+func ReadKeys(path, passphrase string) ([]crypto.PrivKey, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open keys file, %w", err)
+	}
+
+	defer file.Close()
+
+	var (
+		keys    []crypto.PrivKey
+		armored []string // the lines of the current armored key, if any
+		line    int
+
+		scanner = bufio.NewScanner(file)
+	)
+
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case armored != nil:
+			// Collect the armored key, until it ends
+			armored = append(armored, text)
+
+			if !strings.HasPrefix(text, armorEnd) {
+				continue
+			}
+
+			key, err := unarmorKey(strings.Join(armored, "\n"), passphrase)
+			if err != nil {
+				return nil, fmt.Errorf("invalid armored key ending at line %d, %w", line, err)
+			}
+
+			keys = append(keys, key)
+			armored = nil
+		case strings.HasPrefix(text, armorBegin):
+			armored = []string{text}
+		case text == "", strings.HasPrefix(text, "#"):
+			continue
+		default:
+			key, err := decodeHexKey(text)
+			if err != nil {
+				return nil, fmt.Errorf("invalid hex key at line %d, %w", line, err)
+			}
+
+			keys = append(keys, key)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read keys file, %w", err)
+	}
+
+	if armored != nil {
+		return nil, errUnterminatedArmor
+	}
+
+	if len(keys) == 0 {
+		return nil, errNoKeys
+	}
+
+	return keys, nil
+}
+
+// unarmorKey extracts the armored private key,
+// decrypting it with the passphrase if encrypted
+func unarmorKey(armorStr, passphrase string) (crypto.PrivKey, error) {
+	if key, err := armor.UnarmorPrivateKey(armorStr); err == nil {
+		return key, nil
+	}
+
+	return armor.UnarmorDecryptPrivKey(armorStr, passphrase)
+}
+
+// decodeHexKey decodes the hex-encoded private key, either
+// as raw secp256k1 key bytes, or as amino-encoded key bytes
+func decodeHexKey(text string) (crypto.PrivKey, error) {
+	bz, err := hex.DecodeString(strings.TrimPrefix(text, "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bz) == len(secp256k1.PrivKeySecp256k1{}) {
+		return secp256k1.PrivKeySecp256k1(bz), nil
+	}
+
+	key, err := crypto.PrivKeyFromBytes(bz)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %d bytes", errInvalidPrivKeySize, len(bz))
+	}
+
+	return key, nil
+}
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/armor"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeysFile writes the keys file with the given lines
+func writeKeysFile(t *testing.T, lines ...string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "keys")
+
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600))
+
+	return path
+}
+
+func TestReadKeys(t *testing.T) {
+	t.Parallel()
+
+	const passphrase = "passphrase"
+
+	t.Run("hex and armored keys", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			hexKey       = secp256k1.GenPrivKey()
+			prefixedKey  = secp256k1.GenPrivKey()
+			aminoKey     = secp256k1.GenPrivKey()
+			armoredKey   = secp256k1.GenPrivKey()
+			encryptedKey = secp256k1.GenPrivKey()
+		)
+
+		path := writeKeysFile(
+			t,
+			"# faucet keys",
+			hex.EncodeToString(hexKey[:]),
+			"0x"+hex.EncodeToString(prefixedKey[:]),
+			"",
+			hex.EncodeToString(aminoKey.Bytes()),
+			armor.ArmorPrivateKey(armoredKey),
+			armor.EncryptArmorPrivKey(encryptedKey, passphrase),
+		)
+
+		keys, err := ReadKeys(path, passphrase)
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]crypto.PrivKey{hexKey, prefixedKey, aminoKey, armoredKey, encryptedKey},
+			keys,
+		)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ReadKeys(filepath.Join(t.TempDir(), "keys"), passphrase)
+		assert.Error(t, err)
+	})
+
+	t.Run("no keys", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ReadKeys(writeKeysFile(t, "# no keys", ""), passphrase)
+		assert.ErrorIs(t, err, errNoKeys)
+	})
+
+	t.Run("invalid hex key", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ReadKeys(writeKeysFile(t, "not a key"), passphrase)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid key size", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ReadKeys(writeKeysFile(t, "abcdef"), passphrase)
+		assert.ErrorIs(t, err, errInvalidPrivKeySize)
+	})
+
+	t.Run("invalid passphrase", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeKeysFile(
+			t,
+			armor.EncryptArmorPrivKey(secp256k1.GenPrivKey(), passphrase),
+		)
+
+		_, err := ReadKeys(path, "invalid")
+		assert.Error(t, err)
+	})
+
+	t.Run("unterminated armored key", func(t *testing.T) {
+		t.Parallel()
+
+		armored := strings.Split(armor.ArmorPrivateKey(secp256k1.GenPrivKey()), "\n")
+
+		_, err := ReadKeys(writeKeysFile(t, armored[:len(armored)-2]...), passphrase)
+		assert.ErrorIs(t, err, errUnterminatedArmor)
+	})
+}
@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"github.com/gnolang/faucet/keyring"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/bip39"
 	"github.com/gnolang/gno/tm2/pkg/crypto/hd"
@@ -13,20 +14,58 @@ type Keyring struct {
 	addresses []crypto.Address
 }
 
-// New initializes the keyring using the provided mnemonics
-func New(mnemonic string, numAccounts uint64) *Keyring {
-	addresses := make([]crypto.Address, numAccounts)
-	keyMap := make(map[crypto.Address]crypto.PrivKey, numAccounts)
+// deriveCfg specifies the key derivation configuration
+type deriveCfg struct {
+	account    uint32 // the BIP44 account
+	startIndex uint32 // the BIP44 address index of the first key
+	passphrase string // the BIP39 passphrase
+}
+
+// New initializes the keyring using the provided mnemonics.
+// By default, the keys are derived at account 0, from index 0, with an
+// empty BIP39 passphrase. The account and the address indexes need to be
+// below 2^31 (non-hardened)
+func New(mnemonic string, numAccounts uint64, opts ...Option) *Keyring {
+	cfg := deriveCfg{
+		account:    0,
+		startIndex: 0,
+		passphrase: "",
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 
 	// Generate the seed
-	seed := bip39.NewSeed(mnemonic, "")
+	seed := bip39.NewSeed(mnemonic, cfg.passphrase)
+
+	keys := make([]crypto.PrivKey, 0, numAccounts)
 
 	for i := uint64(0); i < numAccounts; i++ {
 		//nolint:gosec // i ranges up to numAccounts which won't overflow uint32
-		key := generateKeyFromSeed(seed, uint32(i))
+		index := cfg.startIndex + uint32(i)
+
+		keys = append(keys, generateKeyFromSeed(seed, cfg.account, index))
+	}
+
+	return NewFromKeys(keys...)
+}
+
+// NewFromKeys initializes the keyring using the provided private keys
+func NewFromKeys(keys ...crypto.PrivKey) *Keyring {
+	var (
+		addresses = make([]crypto.Address, 0, len(keys))
+		keyMap    = make(map[crypto.Address]crypto.PrivKey, len(keys))
+	)
+
+	for _, key := range keys {
 		address := key.PubKey().Address()
 
-		addresses[i] = address
+		if _, exists := keyMap[address]; exists {
+			continue
+		}
+
+		addresses = append(addresses, address)
 		keyMap[address] = key
 	}
 
@@ -41,20 +80,25 @@ func (k *Keyring) GetAddresses() []crypto.Address {
 	return k.addresses
 }
 
-// GetKey fetches the private key associated with the specified address
-func (k *Keyring) GetKey(address crypto.Address) crypto.PrivKey {
-	return k.keyMap[address]
+// GetSigner fetches the signer associated with the specified address
+func (k *Keyring) GetSigner(address crypto.Address) keyring.Signer {
+	key, exists := k.keyMap[address]
+	if !exists {
+		return nil
+	}
+
+	return keyring.NewKeySigner(key)
 }
 
 // generateKeyFromSeed generates a private key from
-// the provided seed and index
-func generateKeyFromSeed(seed []byte, index uint32) crypto.PrivKey {
-	pathParams := hd.NewFundraiserParams(0, crypto.CoinType, index)
+// the provided seed, account and index
+func generateKeyFromSeed(seed []byte, account, index uint32) crypto.PrivKey {
+	pathParams := hd.NewFundraiserParams(account, crypto.CoinType, index)
 
 	masterPriv, ch := hd.ComputeMastersFromSeed(seed)
 
 	//nolint:errcheck // This derivation can never error out, since the path params
-	// are always going to be valid
+	// are always going to be valid (the account and index are non-hardened)
 	derivedPriv, _ := hd.DerivePrivateKeyForPath(masterPriv, ch, pathParams.String())
 
 	return secp256k1.PrivKeySecp256k1(derivedPriv)
@@ -3,7 +3,9 @@ package memory
 import (
 	"testing"
 
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/bip39"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -64,7 +66,7 @@ func TestKeyring_GetAddresses(t *testing.T) {
 	}
 }
 
-func TestKeyring_GetKey(t *testing.T) {
+func TestKeyring_GetSigner(t *testing.T) {
 	t.Parallel()
 
 	var (
@@ -81,10 +83,83 @@ func TestKeyring_GetKey(t *testing.T) {
 	// Make sure the addresses are valid
 	assert.Len(t, addresses, int(numAccounts))
 
-	// Fetch the key associated with an address
+	// Fetch the signer associated with an address
 	address := addresses[0]
-	key := kr.GetKey(address)
+	signer := kr.GetSigner(address)
 
-	// Make sure the key matches the address
-	assert.Equal(t, address, key.PubKey().Address())
+	// Make sure the signer matches the address
+	assert.Equal(t, address, signer.PubKey().Address())
+}
+
+func TestKeyring_DerivationPath(t *testing.T) {
+	t.Parallel()
+
+	mnemonic := generateTestMnemonic(t)
+
+	t.Run("start index", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			kr      = New(mnemonic, 3, WithStartIndex(10))
+			derived = New(mnemonic, 13)
+		)
+
+		// Make sure the keys are derived from the start index
+		assert.Equal(t, derived.GetAddresses()[10:], kr.GetAddresses())
+	})
+
+	t.Run("account", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			kr    = New(mnemonic, 1, WithAccount(1))
+			other = New(mnemonic, 1)
+		)
+
+		// Make sure the key is derived at the account
+		expectedKey := generateKeyFromSeed(bip39.NewSeed(mnemonic, ""), 1, 0)
+
+		assert.Equal(t, []crypto.Address{expectedKey.PubKey().Address()}, kr.GetAddresses())
+		assert.NotEqual(t, other.GetAddresses(), kr.GetAddresses())
+	})
+
+	t.Run("passphrase", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			kr    = New(mnemonic, 1, WithPassphrase("passphrase"))
+			other = New(mnemonic, 1)
+		)
+
+		// Make sure the key is derived from the passphrase seed
+		expectedKey := generateKeyFromSeed(bip39.NewSeed(mnemonic, "passphrase"), 0, 0)
+
+		assert.Equal(t, []crypto.Address{expectedKey.PubKey().Address()}, kr.GetAddresses())
+		assert.NotEqual(t, other.GetAddresses(), kr.GetAddresses())
+	})
+}
+
+func TestKeyring_NewFromKeys(t *testing.T) {
+	t.Parallel()
+
+	var (
+		first  = secp256k1.GenPrivKey()
+		second = secp256k1.GenPrivKey()
+	)
+
+	// Create the keyring, with a duplicate key
+	kr := NewFromKeys(first, second, first)
+
+	// Make sure the keys are loaded once, in order
+	assert.Equal(
+		t,
+		[]crypto.Address{
+			first.PubKey().Address(),
+			second.PubKey().Address(),
+		},
+		kr.GetAddresses(),
+	)
+
+	assert.Equal(t, second.PubKey(), kr.GetSigner(second.PubKey().Address()).PubKey())
+	assert.Nil(t, kr.GetSigner(crypto.Address{1}))
 }
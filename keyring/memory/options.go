@@ -0,0 +1,25 @@
+package memory
+
+type Option func(c *deriveCfg)
+
+// WithAccount specifies the BIP44 account of the derived keys
+func WithAccount(account uint32) Option {
+	return func(c *deriveCfg) {
+		c.account = account
+	}
+}
+
+// WithStartIndex specifies the BIP44 address index
+// of the first derived key
+func WithStartIndex(index uint32) Option {
+	return func(c *deriveCfg) {
+		c.startIndex = index
+	}
+}
+
+// WithPassphrase specifies the BIP39 passphrase of the mnemonic
+func WithPassphrase(passphrase string) Option {
+	return func(c *deriveCfg) {
+		c.passphrase = passphrase
+	}
+}
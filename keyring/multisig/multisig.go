@@ -0,0 +1,174 @@
+package multisig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	tm2Multisig "github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+)
+
+var (
+	errInvalidThreshold = errors.New("invalid multisig threshold")
+	errNotEnoughMembers = errors.New("not enough multisig member keys")
+)
+
+// Account is a K of N threshold multisig account
+type Account struct {
+	Threshold int             // the number of member signatures required (K)
+	PubKeys   []crypto.PubKey // the member public keys (N), in multisig order
+}
+
+// Keyring is a keyring of multisig faucet accounts, with the member keys
+// held by a base keyring. The multisig accounts come first, followed by the
+// base keyring accounts that aren't multisig members
+type Keyring struct {
+	base keyring.Keyring
+
+	signers   map[crypto.Address]*Signer
+	addresses []crypto.Address
+}
+
+// New creates a new multisig keyring, for the given accounts.
+// The base keyring needs to hold the threshold of member keys for each account
+func New(base keyring.Keyring, accounts ...Account) (*Keyring, error) {
+	var (
+		signers   = make(map[crypto.Address]*Signer, len(accounts))
+		addresses = make([]crypto.Address, 0, len(accounts))
+		members   = make([]crypto.Address, 0)
+	)
+
+	for i, account := range accounts {
+		// Find the member keys held by the base keyring
+		memberSigners := make([]keyring.Signer, 0, len(account.PubKeys))
+
+		for _, pubKey := range account.PubKeys {
+			memberSigner := base.GetSigner(pubKey.Address())
+			if memberSigner == nil {
+				continue
+			}
+
+			memberSigners = append(memberSigners, memberSigner)
+			members = append(members, pubKey.Address())
+		}
+
+		signer, err := NewSigner(account.Threshold, account.PubKeys, memberSigners...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid multisig account %d, %w", i, err)
+		}
+
+		address := signer.PubKey().Address()
+
+		if _, exists := signers[address]; exists {
+			continue
+		}
+
+		signers[address] = signer
+		addresses = append(addresses, address)
+	}
+
+	// Keep the base accounts that aren't multisig members
+	for _, address := range base.GetAddresses() {
+		if slices.Contains(members, address) {
+			continue
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	return &Keyring{
+		base:      base,
+		signers:   signers,
+		addresses: addresses,
+	}, nil
+}
+
+// GetAddresses fetches the addresses in the keyring
+func (k *Keyring) GetAddresses() []crypto.Address {
+	return k.addresses
+}
+
+// GetSigner fetches the signer associated with the specified address
+func (k *Keyring) GetSigner(address crypto.Address) keyring.Signer {
+	if signer, exists := k.signers[address]; exists {
+		return signer
+	}
+
+	if !slices.Contains(k.addresses, address) {
+		// Multisig members only sign for the multisig
+		return nil
+	}
+
+	return k.base.GetSigner(address)
+}
+
+// Signer is a K of N threshold multisig signer, that assembles
+// the multisig signature from the member signatures
+type Signer struct {
+	pubKey  tm2Multisig.PubKeyMultisigThreshold
+	members []keyring.Signer
+}
+
+// NewSigner creates a new multisig signer, for the given threshold and member
+// public keys. The member signers need to cover the threshold, and only
+// the threshold of them sign
+func NewSigner(threshold int, pubKeys []crypto.PubKey, members ...keyring.Signer) (*Signer, error) {
+	if threshold < 1 || threshold > len(pubKeys) {
+		return nil, fmt.Errorf("%w, %d of %d", errInvalidThreshold, threshold, len(pubKeys))
+	}
+
+	signers := make([]keyring.Signer, 0, threshold)
+
+	for _, member := range members {
+		if !slices.ContainsFunc(pubKeys, member.PubKey().Equals) {
+			return nil, fmt.Errorf("key %s is not a multisig member", member.PubKey().Address())
+		}
+
+		if len(signers) < threshold {
+			signers = append(signers, member)
+		}
+	}
+
+	if len(signers) < threshold {
+		return nil, fmt.Errorf("%w, have %d of %d", errNotEnoughMembers, len(signers), threshold)
+	}
+
+	//nolint:errcheck // the threshold is validated above
+	pubKey, _ := tm2Multisig.NewPubKeyMultisigThreshold(threshold, pubKeys).(tm2Multisig.PubKeyMultisigThreshold)
+
+	return &Signer{
+		pubKey:  pubKey,
+		members: signers,
+	}, nil
+}
+
+// PubKey returns the multisig public key
+func (s *Signer) PubKey() crypto.PubKey {
+	return s.pubKey
+}
+
+// Sign signs the payload with the threshold of member keys,
+// and returns the (amino-encoded) multisig signature
+func (s *Signer) Sign(ctx context.Context, msg []byte) ([]byte, error) {
+	multisignature := tm2Multisig.NewMultisig(len(s.pubKey.PubKeys))
+
+	for _, member := range s.members {
+		signature, err := member.Sign(ctx, msg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to sign with member %s, %w", member.PubKey().Address(), err)
+		}
+
+		if err = multisignature.AddSignatureFromPubKey(
+			signature,
+			member.PubKey(),
+			s.pubKey.PubKeys,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	return multisignature.Marshal(), nil
+}
@@ -0,0 +1,137 @@
+package multisig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/faucet/keyring/memory"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateKeys generates n random private keys
+func generateKeys(n int) ([]crypto.PrivKey, []crypto.PubKey) {
+	var (
+		privKeys = make([]crypto.PrivKey, 0, n)
+		pubKeys  = make([]crypto.PubKey, 0, n)
+	)
+
+	for range n {
+		key := secp256k1.GenPrivKey()
+
+		privKeys = append(privKeys, key)
+		pubKeys = append(pubKeys, key.PubKey())
+	}
+
+	return privKeys, pubKeys
+}
+
+func TestSigner_NewSigner(t *testing.T) {
+	t.Parallel()
+
+	privKeys, pubKeys := generateKeys(3)
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewSigner(4, pubKeys, keyring.NewKeySigner(privKeys[0]), keyring.NewKeySigner(privKeys[1]))
+		assert.ErrorIs(t, err, errInvalidThreshold)
+
+		_, err = NewSigner(0, pubKeys, keyring.NewKeySigner(privKeys[0]), keyring.NewKeySigner(privKeys[1]))
+		assert.ErrorIs(t, err, errInvalidThreshold)
+	})
+
+	t.Run("not enough members", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewSigner(2, pubKeys, keyring.NewKeySigner(privKeys[0]))
+		assert.ErrorIs(t, err, errNotEnoughMembers)
+	})
+
+	t.Run("unknown member", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewSigner(1, pubKeys, keyring.NewKeySigner(secp256k1.GenPrivKey()))
+		assert.Error(t, err)
+	})
+}
+
+func TestSigner_Sign(t *testing.T) {
+	t.Parallel()
+
+	var (
+		privKeys, pubKeys = generateKeys(3)
+		msg               = []byte("sign bytes")
+	)
+
+	// Hold only the 2 of 3 threshold of member keys
+	signer, err := NewSigner(2, pubKeys, keyring.NewKeySigner(privKeys[0]), keyring.NewKeySigner(privKeys[2]))
+	require.NoError(t, err)
+
+	signature, err := signer.Sign(context.Background(), msg)
+	require.NoError(t, err)
+
+	// Make sure the multisig signature is valid
+	assert.True(t, signer.PubKey().VerifyBytes(msg, signature))
+	assert.False(t, signer.PubKey().VerifyBytes([]byte("other bytes"), signature))
+}
+
+func TestKeyring_New(t *testing.T) {
+	t.Parallel()
+
+	var (
+		privKeys, pubKeys = generateKeys(3)
+		extraKey          = secp256k1.GenPrivKey()
+	)
+
+	t.Run("not enough member keys", func(t *testing.T) {
+		t.Parallel()
+
+		base := memory.NewFromKeys(privKeys[0], extraKey)
+
+		_, err := New(base, Account{
+			Threshold: 2,
+			PubKeys:   pubKeys,
+		})
+		assert.ErrorIs(t, err, errNotEnoughMembers)
+	})
+
+	t.Run("multisig accounts exposed", func(t *testing.T) {
+		t.Parallel()
+
+		base := memory.NewFromKeys(privKeys[0], privKeys[1], extraKey)
+
+		kr, err := New(base, Account{
+			Threshold: 2,
+			PubKeys:   pubKeys,
+		})
+		require.NoError(t, err)
+
+		multisigAddress := kr.GetAddresses()[0]
+
+		// Make sure the multisig account comes first,
+		// and the member accounts are hidden
+		assert.Equal(
+			t,
+			[]crypto.Address{multisigAddress, extraKey.PubKey().Address()},
+			kr.GetAddresses(),
+		)
+
+		assert.Nil(t, kr.GetSigner(privKeys[0].PubKey().Address()))
+		assert.NotNil(t, kr.GetSigner(extraKey.PubKey().Address()))
+
+		// Make sure the multisig signer signs for the multisig address
+		signer := kr.GetSigner(multisigAddress)
+		require.NotNil(t, signer)
+
+		assert.Equal(t, multisigAddress, signer.PubKey().Address())
+
+		signature, err := signer.Sign(context.Background(), []byte("sign bytes"))
+		require.NoError(t, err)
+
+		assert.True(t, signer.PubKey().VerifyBytes([]byte("sign bytes"), signature))
+	})
+}
@@ -0,0 +1,23 @@
+package remote
+
+import (
+	"net/http"
+	"time"
+)
+
+type Option func(k *Keyring)
+
+// WithHTTPClient specifies the HTTP client
+// used to reach the remote signer
+func WithHTTPClient(client *http.Client) Option {
+	return func(k *Keyring) {
+		k.client = client
+	}
+}
+
+// WithTimeout specifies the remote signer request timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(k *Keyring) {
+		k.timeout = timeout
+	}
+}
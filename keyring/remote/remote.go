@@ -0,0 +1,198 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	_ "github.com/gnolang/gno/tm2/pkg/crypto/secp256k1" // register the secp256k1 public keys
+)
+
+// DefaultTimeout is the default remote signer request timeout
+const DefaultTimeout = 10 * time.Second
+
+var (
+	errNoKeys           = errors.New("no keys in the remote signer")
+	errKeyMismatch      = errors.New("public key does not match the address")
+	errInvalidSignature = errors.New("invalid signature from the remote signer")
+)
+
+// Keyring is a keyring that delegates signing to a remote signer,
+// over a simple HTTP signing API. The private keys never leave the signer:
+//   - GET /keys lists the signer addresses and (bech32) public keys
+//   - POST /sign signs the (base64) sign bytes with the key of the address
+type Keyring struct {
+	url     string
+	client  *http.Client
+	timeout time.Duration
+
+	signers   map[crypto.Address]*signer
+	addresses []crypto.Address
+}
+
+// New creates a new remote signer keyring, with the keys
+// fetched from the remote signer at the given URL
+func New(ctx context.Context, url string, opts ...Option) (*Keyring, error) {
+	k := &Keyring{
+		url:     strings.TrimSuffix(url, "/"),
+		client:  http.DefaultClient,
+		timeout: DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	// Fetch the signer keys
+	var response keysResponse
+
+	if err := k.do(ctx, http.MethodGet, keysPath, nil, &response); err != nil {
+		return nil, fmt.Errorf("unable to fetch keys, %w", err)
+	}
+
+	if len(response.Keys) == 0 {
+		return nil, errNoKeys
+	}
+
+	k.signers = make(map[crypto.Address]*signer, len(response.Keys))
+	k.addresses = make([]crypto.Address, 0, len(response.Keys))
+
+	for _, info := range response.Keys {
+		address, err := crypto.AddressFromBech32(info.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key address %q, %w", info.Address, err)
+		}
+
+		pubKey, err := crypto.PubKeyFromBech32(info.PubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key for %s, %w", info.Address, err)
+		}
+
+		if pubKey.Address() != address {
+			return nil, fmt.Errorf("%w, %s", errKeyMismatch, info.Address)
+		}
+
+		if _, exists := k.signers[address]; exists {
+			continue
+		}
+
+		k.signers[address] = &signer{
+			keyring: k,
+			address: address,
+			pubKey:  pubKey,
+		}
+		k.addresses = append(k.addresses, address)
+	}
+
+	return k, nil
+}
+
+// GetAddresses fetches the addresses in the keyring
+func (k *Keyring) GetAddresses() []crypto.Address {
+	return k.addresses
+}
+
+// GetSigner fetches the signer associated with the specified address
+func (k *Keyring) GetSigner(address crypto.Address) keyring.Signer {
+	s, exists := k.signers[address]
+	if !exists {
+		return nil
+	}
+
+	return s
+}
+
+// do executes the remote signer request,
+// and decodes the response into the result
+func (k *Keyring) do(ctx context.Context, method, path string, body, result any) error {
+	ctx, cancelFn := context.WithTimeout(ctx, k.timeout)
+	defer cancelFn()
+
+	var reqBody io.Reader
+
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("unable to encode request, %w", err)
+		}
+
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, k.url+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("unable to create request, %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to reach remote signer, %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResponse errorResponse
+
+		_ = json.NewDecoder(resp.Body).Decode(&errResponse) //nolint:errcheck // the error message is optional
+
+		return fmt.Errorf("remote signer responded with %d: %s", resp.StatusCode, errResponse.Error)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("unable to decode response, %w", err)
+	}
+
+	return nil
+}
+
+// signer is a single remote signer key
+type signer struct {
+	keyring *Keyring
+
+	address crypto.Address
+	pubKey  crypto.PubKey
+}
+
+// PubKey returns the public key of the signer
+func (s *signer) PubKey() crypto.PubKey {
+	return s.pubKey
+}
+
+// Sign signs the given payload on the remote signer, within the context
+// and the signer timeout, and makes sure the signature is valid for the signer key
+func (s *signer) Sign(ctx context.Context, msg []byte) ([]byte, error) {
+	var (
+		request = signRequest{
+			Address:   s.address.String(),
+			SignBytes: msg,
+		}
+		response signResponse
+	)
+
+	if err := s.keyring.do(
+		ctx,
+		http.MethodPost,
+		signPath,
+		request,
+		&response,
+	); err != nil {
+		return nil, fmt.Errorf("unable to sign remotely, %w", err)
+	}
+
+	if !s.pubKey.VerifyBytes(msg, response.Signature) {
+		return nil, errInvalidSignature
+	}
+
+	return response.Signature, nil
+}
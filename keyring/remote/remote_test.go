@@ -0,0 +1,244 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gnolang/faucet/keyring/memory"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/bip39"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateTestMnemonic generates a new test BIP39 mnemonic
+func generateTestMnemonic(t *testing.T) string {
+	t.Helper()
+
+	entropySeed, err := bip39.NewEntropy(256)
+	require.NoError(t, err)
+
+	mnemonic, err := bip39.NewMnemonic(entropySeed)
+	require.NoError(t, err)
+
+	return mnemonic
+}
+
+// newTestSigner starts a stand-in remote signer,
+// backed by an in-memory keyring
+func newTestSigner(t *testing.T, numAccounts uint64) (*httptest.Server, *memory.Keyring) {
+	t.Helper()
+
+	kr := memory.New(generateTestMnemonic(t), numAccounts)
+
+	srv := httptest.NewServer(NewServer(kr))
+	t.Cleanup(srv.Close)
+
+	return srv, kr
+}
+
+// newTestHandler starts a remote signer serving the given
+// keys, with the given sign handler, if any
+func newTestHandler(t *testing.T, keys []keyInfo, signFn http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(keysPath, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, keysResponse{
+			Keys: keys,
+		})
+	})
+
+	if signFn != nil {
+		mux.HandleFunc(signPath, signFn)
+	}
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// newKeyInfo creates the public key information for the given key
+func newKeyInfo(key crypto.PrivKey) keyInfo {
+	return keyInfo{
+		Address: key.PubKey().Address().String(),
+		PubKey:  crypto.PubKeyToBech32(key.PubKey()),
+	}
+}
+
+func TestKeyring_New(t *testing.T) {
+	t.Parallel()
+
+	t.Run("keys fetched", func(t *testing.T) {
+		t.Parallel()
+
+		srv, local := newTestSigner(t, 3)
+
+		kr, err := New(context.Background(), srv.URL)
+		require.NoError(t, err)
+
+		assert.Equal(t, local.GetAddresses(), kr.GetAddresses())
+
+		for _, address := range local.GetAddresses() {
+			signer := kr.GetSigner(address)
+			require.NotNil(t, signer)
+
+			assert.Equal(t, local.GetSigner(address).PubKey(), signer.PubKey())
+		}
+
+		assert.Nil(t, kr.GetSigner(crypto.Address{1}))
+	})
+
+	t.Run("unreachable signer", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _ := newTestSigner(t, 1)
+		srv.Close()
+
+		_, err := New(context.Background(), srv.URL)
+		assert.Error(t, err)
+	})
+
+	t.Run("no keys", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newTestHandler(t, nil, nil)
+
+		_, err := New(context.Background(), srv.URL)
+		assert.ErrorIs(t, err, errNoKeys)
+	})
+
+	t.Run("public key mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		info := newKeyInfo(secp256k1.GenPrivKey())
+		info.Address = secp256k1.GenPrivKey().PubKey().Address().String()
+
+		srv := newTestHandler(t, []keyInfo{info}, nil)
+
+		_, err := New(context.Background(), srv.URL)
+		assert.ErrorIs(t, err, errKeyMismatch)
+	})
+}
+
+func TestKeyring_Sign(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("sign bytes")
+
+	t.Run("remote signature", func(t *testing.T) {
+		t.Parallel()
+
+		srv, local := newTestSigner(t, 2)
+
+		kr, err := New(context.Background(), srv.URL)
+		require.NoError(t, err)
+
+		for _, address := range local.GetAddresses() {
+			signature, err := kr.GetSigner(address).Sign(context.Background(), msg)
+			require.NoError(t, err)
+
+			assert.True(t, local.GetSigner(address).PubKey().VerifyBytes(msg, signature))
+		}
+	})
+
+	t.Run("signer error", func(t *testing.T) {
+		t.Parallel()
+
+		key := secp256k1.GenPrivKey()
+
+		srv := newTestHandler(
+			t,
+			[]keyInfo{newKeyInfo(key)},
+			func(w http.ResponseWriter, _ *http.Request) {
+				writeError(w, http.StatusForbidden, "signing disabled")
+			},
+		)
+
+		kr, err := New(context.Background(), srv.URL)
+		require.NoError(t, err)
+
+		_, err = kr.GetSigner(key.PubKey().Address()).Sign(context.Background(), msg)
+		require.Error(t, err)
+
+		assert.Contains(t, err.Error(), "signing disabled")
+	})
+
+	t.Run("signing within the context and timeout", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			key       = secp256k1.GenPrivKey()
+			releaseCh = make(chan struct{})
+		)
+
+		srv := newTestHandler(
+			t,
+			[]keyInfo{newKeyInfo(key)},
+			func(_ http.ResponseWriter, _ *http.Request) {
+				// The signer doesn't respond in time
+				<-releaseCh
+			},
+		)
+
+		// Release the signer before the server is closed
+		t.Cleanup(func() {
+			close(releaseCh)
+		})
+
+		kr, err := New(context.Background(), srv.URL, WithTimeout(50*time.Millisecond))
+		require.NoError(t, err)
+
+		signer := kr.GetSigner(key.PubKey().Address())
+
+		// Make sure the canceled context stops the signing
+		ctx, cancelFn := context.WithCancel(context.Background())
+		cancelFn()
+
+		_, err = signer.Sign(ctx, msg)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		// Make sure the signer timeout applies
+		_, err = signer.Sign(context.Background(), msg)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			key   = secp256k1.GenPrivKey()
+			other = secp256k1.GenPrivKey()
+		)
+
+		srv := newTestHandler(
+			t,
+			[]keyInfo{newKeyInfo(key)},
+			func(w http.ResponseWriter, r *http.Request) {
+				var request signRequest
+
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+				// Sign with a different key
+				signature, err := other.Sign(request.SignBytes)
+				require.NoError(t, err)
+
+				writeJSON(w, http.StatusOK, signResponse{
+					Signature: signature,
+				})
+			},
+		)
+
+		kr, err := New(context.Background(), srv.URL)
+		require.NoError(t, err)
+
+		_, err = kr.GetSigner(key.PubKey().Address()).Sign(context.Background(), msg)
+		assert.ErrorIs(t, err, errInvalidSignature)
+	})
+}
@@ -0,0 +1,105 @@
+package remote
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/go-chi/chi/v5"
+)
+
+// Server is a stand-in remote signer, serving the HTTP signing API
+// for the keys of an in-process keyring. It's meant for tests and local
+// setups, where running a dedicated signer is not worth the trouble
+type Server struct {
+	keyring keyring.Keyring
+	mux     *chi.Mux
+}
+
+// NewServer creates a new stand-in remote signer for the given keyring
+func NewServer(kr keyring.Keyring) *Server {
+	s := &Server{
+		keyring: kr,
+		mux:     chi.NewMux(),
+	}
+
+	s.mux.Get(keysPath, s.handleKeys)
+	s.mux.Post(signPath, s.handleSign)
+
+	return s
+}
+
+// ServeHTTP serves the HTTP signing API
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleKeys lists the keyring addresses and public keys
+func (s *Server) handleKeys(w http.ResponseWriter, _ *http.Request) {
+	addresses := s.keyring.GetAddresses()
+
+	response := keysResponse{
+		Keys: make([]keyInfo, 0, len(addresses)),
+	}
+
+	for _, address := range addresses {
+		response.Keys = append(response.Keys, keyInfo{
+			Address: address.String(),
+			PubKey:  crypto.PubKeyToBech32(s.keyring.GetSigner(address).PubKey()),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleSign signs the payload with the key of the requested address
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	var request signRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid sign request")
+
+		return
+	}
+
+	address, err := crypto.AddressFromBech32(request.Address)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid address")
+
+		return
+	}
+
+	signer := s.keyring.GetSigner(address)
+	if signer == nil {
+		writeError(w, http.StatusNotFound, "unknown address")
+
+		return
+	}
+
+	signature, err := signer.Sign(r.Context(), request.SignBytes)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "unable to sign")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, signResponse{
+		Signature: signature,
+	})
+}
+
+// writeJSON writes the JSON response with the given status
+func writeJSON(w http.ResponseWriter, status int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(response) //nolint:errcheck // Fine to leave unchecked
+}
+
+// writeError writes the JSON error response with the given status
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{
+		Error: message,
+	})
+}
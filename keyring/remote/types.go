@@ -0,0 +1,33 @@
+package remote
+
+const (
+	keysPath = "/keys" // the endpoint listing the signer keys
+	signPath = "/sign" // the endpoint signing payloads
+)
+
+// keyInfo is the public information of a signer key
+type keyInfo struct {
+	Address string `json:"address"` // the bech32 address of the key
+	PubKey  string `json:"pubKey"`  // the bech32 public key
+}
+
+// keysResponse is the response of the keys endpoint
+type keysResponse struct {
+	Keys []keyInfo `json:"keys"`
+}
+
+// signRequest is the request of the sign endpoint
+type signRequest struct {
+	Address   string `json:"address"`   // the bech32 address of the signing key
+	SignBytes []byte `json:"signBytes"` // the payload to sign (base64)
+}
+
+// signResponse is the response of the sign endpoint
+type signResponse struct {
+	Signature []byte `json:"signature"` // the payload signature (base64)
+}
+
+// errorResponse is the response of failed requests
+type errorResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,101 @@
+package faucet
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// memoData is the data available to the transaction memo template.
+// For batched drips, each field lists the values of every drip, comma separated
+type memoData struct {
+	RequestID   string // the JSON-RPC request ID of the drip
+	Beneficiary string // the beneficiary address of the drip
+	Route       string // the route pattern the drip was requested on
+	Tag         string // the custom faucet tag
+}
+
+// dripInfo is the request information of a drip
+type dripInfo struct {
+	requestID string // the JSON-RPC request ID
+	route     string // the route pattern
+}
+
+type dripInfoKey struct{}
+
+// withDripInfo attaches the drip request information to the context
+func withDripInfo(ctx context.Context, requestID uint) context.Context {
+	info := dripInfo{
+		requestID: strconv.FormatUint(uint64(requestID), 10),
+	}
+
+	if routeCtx := chi.RouteContext(ctx); routeCtx != nil {
+		info.route = routeCtx.RoutePattern()
+	}
+
+	return context.WithValue(ctx, dripInfoKey{}, info)
+}
+
+// getDripInfo returns the drip request information from the context, if any
+func getDripInfo(ctx context.Context) dripInfo {
+	info, _ := ctx.Value(dripInfoKey{}).(dripInfo)
+
+	return info
+}
+
+// newMemoTemplate parses the transaction memo template,
+// and makes sure it only references the available memo data
+func newMemoTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("memo").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tmpl.Execute(&strings.Builder{}, memoData{}); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// prepareMemo renders the transaction memo for the given transfers, if enabled
+func (f *Faucet) prepareMemo(transfers []transfer) (string, error) {
+	if f.memoTemplate == nil {
+		return "", nil
+	}
+
+	var (
+		requestIDs    = make([]string, 0, len(transfers))
+		beneficiaries = make([]string, 0, len(transfers))
+		routes        = make([]string, 0, 1)
+	)
+
+	for _, t := range transfers {
+		requestIDs = append(requestIDs, t.info.requestID)
+		beneficiaries = append(beneficiaries, t.to.String())
+
+		if !slices.Contains(routes, t.info.route) {
+			routes = append(routes, t.info.route)
+		}
+	}
+
+	data := memoData{
+		RequestID:   strings.Join(requestIDs, ","),
+		Beneficiary: strings.Join(beneficiaries, ","),
+		Route:       strings.Join(routes, ","),
+		Tag:         f.config.MemoTag,
+	}
+
+	var memo strings.Builder
+
+	if err := f.memoTemplate.Execute(&memo, data); err != nil {
+		return "", fmt.Errorf("unable to render memo, %w", err)
+	}
+
+	return memo.String(), nil
+}
@@ -0,0 +1,153 @@
+package faucet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/spec"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMemoTemplate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown field", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newMemoTemplate("faucet {{.Unknown}}")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid template", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newMemoTemplate("faucet {{.RequestID}} {{.Beneficiary}} {{.Route}} {{.Tag}}")
+		assert.NoError(t, err)
+	})
+}
+
+func TestFaucet_Memo(t *testing.T) {
+	t.Parallel()
+
+	// newMemoFaucet creates a faucet with the memo template
+	// set, which captures the memos of the sent transactions
+	newMemoFaucet := func(t *testing.T, cfg *config.Config) (*Faucet, func() []string) {
+		t.Helper()
+
+		var (
+			memos []string
+			mux   sync.Mutex
+
+			mockClient = &mockClient{
+				sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					mux.Lock()
+					defer mux.Unlock()
+
+					memos = append(memos, tx.Memo)
+
+					return &coreTypes.ResultBroadcastTxCommit{}, nil
+				},
+			}
+		)
+
+		cfg.MemoTemplate = "faucet:{{.Tag}} route:{{.Route}} id:{{.RequestID}} to:{{.Beneficiary}}"
+		cfg.MemoTag = "testnet"
+
+		f := newTestFaucet(t, cfg, mockClient, nil)
+
+		return f, func() []string {
+			mux.Lock()
+			defer mux.Unlock()
+
+			return memos
+		}
+	}
+
+	t.Run("memo rendered for drip", func(t *testing.T) {
+		t.Parallel()
+
+		f, getMemos := newMemoFaucet(t, config.DefaultConfig())
+
+		// Send the drip through the faucet router,
+		// so the route pattern is resolved
+		beneficiary := crypto.Address{2}
+
+		body, err := json.Marshal(spec.NewJSONRequest(
+			7,
+			DefaultDripMethod,
+			[]any{beneficiary.String(), "100ugnot"},
+		))
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		f.mux.ServeHTTP(
+			recorder,
+			httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)),
+		)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		assert.Equal(
+			t,
+			[]string{"faucet:testnet route:/ id:7 to:" + beneficiary.String()},
+			getMemos(),
+		)
+	})
+
+	t.Run("memo rendered for batch", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.DefaultConfig()
+		cfg.BatchWindow = 50 * time.Millisecond
+
+		f, getMemos := newMemoFaucet(t, cfg)
+
+		var (
+			wg sync.WaitGroup
+
+			first  = crypto.Address{2}
+			second = crypto.Address{3}
+		)
+
+		for i, beneficiary := range []crypto.Address{first, second} {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, err := f.transferFunds(
+					withDripInfo(context.Background(), uint(i+1)),
+					beneficiary,
+					std.NewCoins(std.NewCoin("ugnot", 100)),
+				)
+				assert.NoError(t, err)
+			}()
+		}
+
+		wg.Wait()
+
+		// Make sure the single memo lists both drips
+		memos := getMemos()
+		require.Len(t, memos, 1)
+
+		assert.Contains(
+			t,
+			[]string{
+				"faucet:testnet route: id:1,2 to:" + first.String() + "," + second.String(),
+				"faucet:testnet route: id:2,1 to:" + second.String() + "," + first.String(),
+			},
+			memos[0],
+		)
+	})
+}
@@ -1,34 +1,31 @@
 package faucet
 
 import (
+	"context"
+	"testing"
+
+	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/estimate"
+	"github.com/gnolang/faucet/keyring"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/require"
 )
 
 type (
-	bytesDelegate         func() []byte
-	signDelegate          func([]byte) ([]byte, error)
-	pubKeyDelegate        func() crypto.PubKey
-	privKeyEqualsDelegate func(key crypto.PrivKey) bool
+	bytesDelegate  func() []byte
+	signDelegate   func([]byte) ([]byte, error)
+	pubKeyDelegate func() crypto.PubKey
 )
 
-type mockPrivKey struct {
-	bytesFn  bytesDelegate
+type mockSigner struct {
 	signFn   signDelegate
 	pubKeyFn pubKeyDelegate
-	equalsFn privKeyEqualsDelegate
 }
 
-func (m *mockPrivKey) Bytes() []byte {
-	if m.bytesFn != nil {
-		return m.bytesFn()
-	}
-
-	return nil
-}
-
-func (m *mockPrivKey) Sign(msg []byte) ([]byte, error) {
+func (m *mockSigner) Sign(_ context.Context, msg []byte) ([]byte, error) {
 	if m.signFn != nil {
 		return m.signFn(msg)
 	}
@@ -36,7 +33,7 @@ func (m *mockPrivKey) Sign(msg []byte) ([]byte, error) {
 	return nil, nil
 }
 
-func (m *mockPrivKey) PubKey() crypto.PubKey {
+func (m *mockSigner) PubKey() crypto.PubKey {
 	if m.pubKeyFn != nil {
 		return m.pubKeyFn()
 	}
@@ -44,14 +41,6 @@ func (m *mockPrivKey) PubKey() crypto.PubKey {
 	return nil
 }
 
-func (m *mockPrivKey) Equals(key crypto.PrivKey) bool {
-	if m.equalsFn != nil {
-		return m.equalsFn(key)
-	}
-
-	return false
-}
-
 type (
 	addressDelegate      func() crypto.Address
 	verifyBytesDelegate  func([]byte, []byte) bool
@@ -108,8 +97,8 @@ func (m *mockPubKey) String() string {
 }
 
 type (
-	estimateGasFeeDelegate    func() std.Coin
-	estimateGasWantedDelegate func(*std.Tx) int64
+	estimateGasFeeDelegate    func(int64) std.Coin
+	estimateGasWantedDelegate func(context.Context, *std.Tx) int64
 )
 
 type mockEstimator struct {
@@ -117,63 +106,93 @@ type mockEstimator struct {
 	estimateGasWantedFn estimateGasWantedDelegate
 }
 
-func (m *mockEstimator) EstimateGasFee() std.Coin {
+func (m *mockEstimator) EstimateGasFee(gasWanted int64) std.Coin {
 	if m.estimateGasFeeFn != nil {
-		return m.estimateGasFeeFn()
+		return m.estimateGasFeeFn(gasWanted)
 	}
 
 	return std.Coin{}
 }
 
-func (m *mockEstimator) EstimateGasWanted(tx *std.Tx) int64 {
+func (m *mockEstimator) EstimateGasWanted(ctx context.Context, tx *std.Tx) int64 {
 	if m.estimateGasWantedFn != nil {
-		return m.estimateGasWantedFn(tx)
+		return m.estimateGasWantedFn(ctx, tx)
 	}
 
 	return 0
 }
 
 type (
-	getAccountDelegate            func(crypto.Address) (std.Account, error)
-	sendTransactionSyncDelegate   func(tx *std.Tx) (*coreTypes.ResultBroadcastTx, error)
-	sendTransactionCommitDelegate func(tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error)
-	statusDelegate                func() (*coreTypes.ResultStatus, error)
+	getAccountDelegate            func(context.Context, crypto.Address) (std.Account, error)
+	getGasPriceDelegate           func(context.Context) (std.GasPrice, error)
+	simulateTransactionDelegate   func(context.Context, *std.Tx) (*abci.ResponseDeliverTx, error)
+	sendTransactionSyncDelegate   func(context.Context, *std.Tx) (*coreTypes.ResultBroadcastTx, error)
+	sendTransactionCommitDelegate func(context.Context, *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error)
+	getTransactionDelegate        func(context.Context, []byte) (*coreTypes.ResultTx, error)
+	statusDelegate                func(context.Context) (*coreTypes.ResultStatus, error)
 )
 
 type mockClient struct {
 	getAccountFn            getAccountDelegate
+	getGasPriceFn           getGasPriceDelegate
+	simulateTransactionFn   simulateTransactionDelegate
 	sendTransactionSyncFn   sendTransactionSyncDelegate
 	sendTransactionCommitFn sendTransactionCommitDelegate
+	getTransactionFn        getTransactionDelegate
 	statusFn                statusDelegate
 }
 
-func (m *mockClient) GetAccount(address crypto.Address) (std.Account, error) {
+func (m *mockClient) GetAccount(ctx context.Context, address crypto.Address) (std.Account, error) {
 	if m.getAccountFn != nil {
-		return m.getAccountFn(address)
+		return m.getAccountFn(ctx, address)
 	}
 
 	return nil, nil
 }
 
-func (m *mockClient) SendTransactionSync(tx *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+func (m *mockClient) GetGasPrice(ctx context.Context) (std.GasPrice, error) {
+	if m.getGasPriceFn != nil {
+		return m.getGasPriceFn(ctx)
+	}
+
+	return std.GasPrice{}, nil
+}
+
+func (m *mockClient) SimulateTransaction(ctx context.Context, tx *std.Tx) (*abci.ResponseDeliverTx, error) {
+	if m.simulateTransactionFn != nil {
+		return m.simulateTransactionFn(ctx, tx)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) SendTransactionSync(ctx context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
 	if m.sendTransactionSyncFn != nil {
-		return m.sendTransactionSyncFn(tx)
+		return m.sendTransactionSyncFn(ctx, tx)
 	}
 
 	return nil, nil
 }
 
-func (m *mockClient) SendTransactionCommit(tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+func (m *mockClient) SendTransactionCommit(ctx context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
 	if m.sendTransactionCommitFn != nil {
-		return m.sendTransactionCommitFn(tx)
+		return m.sendTransactionCommitFn(ctx, tx)
 	}
 
 	return nil, nil
 }
 
-func (m *mockClient) Status() (*coreTypes.ResultStatus, error) {
+func (m *mockClient) GetTransaction(ctx context.Context, hash []byte) (*coreTypes.ResultTx, error) {
+	if m.getTransactionFn != nil {
+		return m.getTransactionFn(ctx, hash)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) Status(ctx context.Context) (*coreTypes.ResultStatus, error) {
 	if m.statusFn != nil {
-		return m.statusFn()
+		return m.statusFn(ctx)
 	}
 
 	return nil, nil
@@ -296,12 +315,12 @@ func (m *mockAccount) String() string {
 
 type (
 	getAddressesDelegate func() []crypto.Address
-	getKeyDelegate       func(crypto.Address) crypto.PrivKey
+	getSignerDelegate    func(crypto.Address) keyring.Signer
 )
 
 type mockKeyring struct {
 	getAddressesFn getAddressesDelegate
-	getKeyFn       getKeyDelegate
+	getSignerFn    getSignerDelegate
 }
 
 func (m *mockKeyring) GetAddresses() []crypto.Address {
@@ -312,10 +331,96 @@ func (m *mockKeyring) GetAddresses() []crypto.Address {
 	return nil
 }
 
-func (m *mockKeyring) GetKey(address crypto.Address) crypto.PrivKey {
-	if m.getKeyFn != nil {
-		return m.getKeyFn(address)
+func (m *mockKeyring) GetSigner(address crypto.Address) keyring.Signer {
+	if m.getSignerFn != nil {
+		return m.getSignerFn(address)
 	}
 
 	return nil
 }
+
+// newTestKeyring creates a mock keyring with the given addresses,
+// where each account signer produces a static signature
+func newTestKeyring(addresses ...crypto.Address) *mockKeyring {
+	return &mockKeyring{
+		getAddressesFn: func() []crypto.Address {
+			return addresses
+		},
+		getSignerFn: func(address crypto.Address) keyring.Signer {
+			for _, a := range addresses {
+				if a != address {
+					continue
+				}
+
+				return &mockSigner{
+					signFn: func(_ []byte) ([]byte, error) {
+						return []byte("signature"), nil
+					},
+					pubKeyFn: func() crypto.PubKey {
+						return &mockPubKey{
+							addressFn: func() crypto.Address {
+								return address
+							},
+						}
+					},
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// newTestFaucet creates a faucet with the given config, client and estimator,
+// and a test keyring with the given faucet accounts (a single account, if none are given).
+// Unless set, the client funds the faucet accounts and commits the transactions,
+// and the estimator charges a 1ugnot gas fee
+func newTestFaucet(
+	t *testing.T,
+	cfg *config.Config,
+	client *mockClient,
+	estimator estimate.Estimator,
+	addresses ...crypto.Address,
+) *Faucet {
+	t.Helper()
+
+	if len(addresses) == 0 {
+		addresses = []crypto.Address{{1}}
+	}
+
+	if client.getAccountFn == nil {
+		client.getAccountFn = func(_ context.Context, address crypto.Address) (std.Account, error) {
+			return std.NewBaseAccount(
+				address,
+				std.NewCoins(std.NewCoin("ugnot", 1000000000)),
+				nil,
+				0,
+				0,
+			), nil
+		}
+	}
+
+	if client.sendTransactionCommitFn == nil {
+		client.sendTransactionCommitFn = func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+			return &coreTypes.ResultBroadcastTxCommit{}, nil
+		}
+	}
+
+	if estimator == nil {
+		estimator = &mockEstimator{
+			estimateGasFeeFn: func(_ int64) std.Coin {
+				return std.NewCoin("ugnot", 1)
+			},
+		}
+	}
+
+	f, err := NewFaucet(
+		estimator,
+		client,
+		WithConfig(cfg),
+		WithKeyring(newTestKeyring(addresses...)),
+	)
+	require.NoError(t, err)
+
+	return f
+}
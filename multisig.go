@@ -0,0 +1,39 @@
+package faucet
+
+import (
+	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/faucet/keyring/multisig"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+// withMultisigAccounts layers the configured multisig accounts
+// onto the keyring, whose keys act as the multisig members
+func withMultisigAccounts(
+	k keyring.Keyring,
+	accounts []config.MultisigAccount,
+) (keyring.Keyring, error) {
+	if len(accounts) == 0 {
+		return k, nil
+	}
+
+	multisigAccounts := make([]multisig.Account, 0, len(accounts))
+
+	for _, account := range accounts {
+		pubKeys := make([]crypto.PubKey, 0, len(account.PubKeys))
+
+		for _, pubKey := range account.PubKeys {
+			//nolint:errcheck // public keys are validated beforehand
+			pub, _ := crypto.PubKeyFromBech32(pubKey)
+
+			pubKeys = append(pubKeys, pub)
+		}
+
+		multisigAccounts = append(multisigAccounts, multisig.Account{
+			Threshold: int(account.Threshold), //nolint:gosec // bounded by the number of keys
+			PubKeys:   pubKeys,
+		})
+	}
+
+	return multisig.New(k, multisigAccounts...)
+}
@@ -0,0 +1,67 @@
+package faucet
+
+import (
+	"testing"
+
+	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/keyring/memory"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaucet_MultisigAccounts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		memberA = secp256k1.GenPrivKey()
+		memberB = secp256k1.GenPrivKey()
+		memberC = secp256k1.GenPrivKey()
+
+		pubKeys = []crypto.PubKey{memberA.PubKey(), memberB.PubKey(), memberC.PubKey()}
+
+		multisigAddress = multisig.NewPubKeyMultisigThreshold(2, pubKeys).Address()
+	)
+
+	cfg := config.DefaultConfig()
+	cfg.MultisigAccounts = []config.MultisigAccount{
+		{
+			Threshold: 2,
+			PubKeys: []string{
+				crypto.PubKeyToBech32(pubKeys[0]),
+				crypto.PubKeyToBech32(pubKeys[1]),
+				crypto.PubKeyToBech32(pubKeys[2]),
+			},
+		},
+	}
+
+	t.Run("multisig account set up", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := NewFaucet(
+			&mockEstimator{},
+			&mockClient{},
+			WithConfig(cfg),
+			WithKeyring(memory.NewFromKeys(memberA, memberC)),
+		)
+		require.NoError(t, err)
+
+		// Make sure the faucet drips from the multisig account
+		assert.Equal(t, []crypto.Address{multisigAddress}, f.keyring.GetAddresses())
+		assert.NotNil(t, f.keyring.GetSigner(multisigAddress))
+	})
+
+	t.Run("not enough member keys", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewFaucet(
+			&mockEstimator{},
+			&mockClient{},
+			WithConfig(cfg),
+			WithKeyring(memory.NewFromKeys(memberB)),
+		)
+		assert.Error(t, err)
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/faucet/selector"
 )
 
 type Option func(f *Faucet)
@@ -47,9 +49,35 @@ func WithRPCHandlers(handlers []Handler) Option {
 }
 
 // WithPrepareTxMessageFn specifies the faucet
-// transaction message constructor
+// transaction message constructor, overriding the configured one
 func WithPrepareTxMessageFn(prepareTxMsgFn PrepareTxMessageFn) Option {
 	return func(f *Faucet) {
-		f.prepareTxMsgFn = prepareTxMsgFn
+		f.prepareTxMsgsFn = singleMessage(prepareTxMsgFn)
+	}
+}
+
+// WithPrepareTxMessagesFn specifies the faucet transaction
+// messages constructor, for drips that consist of multiple
+// messages, overriding the configured one
+func WithPrepareTxMessagesFn(prepareTxMsgsFn PrepareTxMessagesFn) Option {
+	return func(f *Faucet) {
+		f.prepareTxMsgsFn = prepareTxMsgsFn
+	}
+}
+
+// WithKeyring specifies the faucet keyring, overriding
+// the in-memory keyring derived from the configured mnemonic.
+// The keyring can be replaced at runtime, using RotateKeyring
+func WithKeyring(k keyring.Keyring) Option {
+	return func(f *Faucet) {
+		f.keyring = newRotatingKeyring(k)
+	}
+}
+
+// WithAccountSelector specifies the faucet account
+// selection strategy, overriding the configured one
+func WithAccountSelector(s selector.Selector) Option {
+	return func(f *Faucet) {
+		f.selector = s
 	}
 }
@@ -0,0 +1,116 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var (
+	ErrUnsupportedDenom  = errors.New("unsupported denomination")
+	ErrAmountAboveMax    = errors.New("amount above max drip amount")
+	ErrAmountBelowMin    = errors.New("amount below min drip amount")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+)
+
+// AmountError describes which denomination violated which limit
+type AmountError struct {
+	err error // the violated limit
+
+	Denom  string `json:"denom"`  // the violating denomination
+	Amount int64  `json:"amount"` // the requested (or required) amount
+	Limit  int64  `json:"limit"`  // the limit for the denomination
+}
+
+func (e *AmountError) Error() string {
+	return fmt.Sprintf(
+		"%s: %d%s (limit %d%s)",
+		e.err.Error(),
+		e.Amount,
+		e.Denom,
+		e.Limit,
+		e.Denom,
+	)
+}
+
+func (e *AmountError) Unwrap() error {
+	return e.err
+}
+
+// Policy is the faucet drip amount policy,
+// with limits for each faucet denomination
+type Policy struct {
+	maxAmount std.Coins // the max drip amount, per denomination
+	minAmount std.Coins // the min drip amount, per denomination (optional)
+}
+
+// New creates a new drip amount policy.
+// The max amount defines the faucet denominations
+func New(maxAmount, minAmount std.Coins) *Policy {
+	return &Policy{
+		maxAmount: maxAmount,
+		minAmount: minAmount,
+	}
+}
+
+// ValidateDrip validates every coin of the drip
+// amount against the limits for its denomination
+func (p *Policy) ValidateDrip(amount std.Coins) error {
+	for _, coin := range amount {
+		maxAmount := p.maxAmount.AmountOf(coin.Denom)
+
+		// Make sure the denomination is handed out by the faucet
+		if maxAmount == 0 {
+			return &AmountError{
+				err:    ErrUnsupportedDenom,
+				Denom:  coin.Denom,
+				Amount: coin.Amount,
+				Limit:  0,
+			}
+		}
+
+		// Make sure the amount is within the limits
+		if coin.Amount > maxAmount {
+			return &AmountError{
+				err:    ErrAmountAboveMax,
+				Denom:  coin.Denom,
+				Amount: coin.Amount,
+				Limit:  maxAmount,
+			}
+		}
+
+		if minAmount := p.minAmount.AmountOf(coin.Denom); coin.Amount < minAmount {
+			return &AmountError{
+				err:    ErrAmountBelowMin,
+				Denom:  coin.Denom,
+				Amount: coin.Amount,
+				Limit:  minAmount,
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckFunding checks if the balance covers the amount and the fee,
+// coin by coin (including the fee denomination)
+func CheckFunding(balance, amount std.Coins, fee std.Coin) error {
+	required := amount
+	if fee.IsPositive() {
+		required = required.Add(std.NewCoins(fee))
+	}
+
+	for _, coin := range required {
+		if available := balance.AmountOf(coin.Denom); available < coin.Amount {
+			return &AmountError{
+				err:    ErrInsufficientFunds,
+				Denom:  coin.Denom,
+				Amount: coin.Amount,
+				Limit:  available,
+			}
+		}
+	}
+
+	return nil
+}
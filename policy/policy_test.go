@@ -0,0 +1,161 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_ValidateDrip(t *testing.T) {
+	t.Parallel()
+
+	var (
+		maxAmount = std.MustParseCoins("1000ugnot,500utest")
+		minAmount = std.MustParseCoins("10utest")
+	)
+
+	testTable := []struct {
+		expectedErr *AmountError
+		name        string
+		amount      std.Coins
+	}{
+		{
+			nil,
+			"valid amount",
+			std.MustParseCoins("1000ugnot,10utest"),
+		},
+		{
+			nil,
+			"valid subset",
+			std.MustParseCoins("100ugnot"),
+		},
+		{
+			&AmountError{
+				err:    ErrUnsupportedDenom,
+				Denom:  "atom",
+				Amount: 1,
+				Limit:  0,
+			},
+			"unsupported denom",
+			std.MustParseCoins("1atom,100ugnot"),
+		},
+		{
+			&AmountError{
+				err:    ErrAmountAboveMax,
+				Denom:  "utest",
+				Amount: 501,
+				Limit:  500,
+			},
+			"partially above max",
+			std.MustParseCoins("100ugnot,501utest"),
+		},
+		{
+			&AmountError{
+				err:    ErrAmountBelowMin,
+				Denom:  "utest",
+				Amount: 5,
+				Limit:  10,
+			},
+			"below min",
+			std.MustParseCoins("5utest"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := New(maxAmount, minAmount)
+
+			err := p.ValidateDrip(testCase.amount)
+			if testCase.expectedErr == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var amountErr *AmountError
+			require.ErrorAs(t, err, &amountErr)
+
+			assert.ErrorIs(t, err, testCase.expectedErr.err)
+			assert.Equal(t, testCase.expectedErr, amountErr)
+		})
+	}
+}
+
+func TestCheckFunding(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		expectedErr *AmountError
+		name        string
+		balance     std.Coins
+		amount      std.Coins
+		fee         std.Coin
+	}{
+		{
+			nil,
+			"funded account",
+			std.MustParseCoins("101ugnot,10utest"),
+			std.MustParseCoins("100ugnot,10utest"),
+			std.NewCoin("ugnot", 1),
+		},
+		{
+			&AmountError{
+				err:    ErrInsufficientFunds,
+				Denom:  "utest",
+				Amount: 10,
+				Limit:  5,
+			},
+			"partially underfunded account",
+			std.MustParseCoins("1000ugnot,5utest"),
+			std.MustParseCoins("100ugnot,10utest"),
+			std.NewCoin("ugnot", 1),
+		},
+		{
+			&AmountError{
+				err:    ErrInsufficientFunds,
+				Denom:  "ugnot",
+				Amount: 101,
+				Limit:  100,
+			},
+			"fee not covered",
+			std.MustParseCoins("100ugnot"),
+			std.MustParseCoins("100ugnot"),
+			std.NewCoin("ugnot", 1),
+		},
+		{
+			&AmountError{
+				err:    ErrInsufficientFunds,
+				Denom:  "ufee",
+				Amount: 1,
+				Limit:  0,
+			},
+			"fee denom missing",
+			std.MustParseCoins("100ugnot"),
+			std.MustParseCoins("100ugnot"),
+			std.NewCoin("ufee", 1),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckFunding(testCase.balance, testCase.amount, testCase.fee)
+			if testCase.expectedErr == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var amountErr *AmountError
+			require.ErrorAs(t, err, &amountErr)
+
+			assert.ErrorIs(t, err, ErrInsufficientFunds)
+			assert.Equal(t, testCase.expectedErr, amountErr)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package faucet
 
 import (
+	"context"
+
 	"github.com/gnolang/faucet/estimate"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -11,6 +14,19 @@ import (
 // constructs the faucet fund transaction message
 type PrepareTxMessageFn func(PrepareCfg) std.Msg
 
+// PrepareTxMessagesFn is the callback method that constructs
+// the faucet fund transaction messages. The messages of a drip
+// are executed atomically, as part of the same transaction
+type PrepareTxMessagesFn func(PrepareCfg) []std.Msg
+
+// singleMessage adapts the single message constructor
+// to the multi-message constructor
+func singleMessage(prepareTxMsgFn PrepareTxMessageFn) PrepareTxMessagesFn {
+	return func(cfg PrepareCfg) []std.Msg {
+		return []std.Msg{prepareTxMsgFn(cfg)}
+	}
+}
+
 // PrepareCfg specifies the tx prepare configuration
 type PrepareCfg struct {
 	SendAmount  std.Coins      // the amount to be sent
@@ -28,22 +44,63 @@ func defaultPrepareTxMessage(cfg PrepareCfg) std.Msg {
 	}
 }
 
-// prepareTransaction prepares the transaction for signing
+// spentAmount returns the amount the drip messages spend from the
+// faucet account, apart from the fee. Unknown messages are assumed
+// to spend the transfer amount, which is counted once per transfer
+func spentAmount(msgs []std.Msg, t transfer) std.Coins {
+	var (
+		spent   = std.Coins{}
+		unknown = false
+	)
+
+	for _, msg := range msgs {
+		switch msg := msg.(type) {
+		case bank.MsgSend:
+			spent = spent.Add(msg.Amount)
+		case vm.MsgCall:
+			spent = spent.Add(msg.Send)
+		default:
+			unknown = true
+		}
+	}
+
+	if unknown {
+		spent = spent.Add(t.amount)
+	}
+
+	return spent
+}
+
+// prepareTransaction prepares the transaction for signing.
+// The transaction contains the messages of every transfer, and the memo
 func prepareTransaction(
+	ctx context.Context,
 	estimator estimate.Estimator,
-	msg std.Msg,
+	signer crypto.PubKey,
+	memo string,
+	msgs ...std.Msg,
 ) *std.Tx {
-	// Construct the transaction
+	// Construct the transaction, with a placeholder
+	// signature for the gas estimation, since simulating
+	// the transaction requires the signer public key
 	tx := &std.Tx{
-		Msgs:       []std.Msg{msg},
-		Signatures: nil,
+		Msgs: msgs,
+		Memo: memo,
+		Signatures: []std.Signature{
+			{
+				PubKey: signer,
+			},
+		},
 	}
 
-	// Prepare the gas fee
-	gasFee := estimator.EstimateGasFee()
-	gasWanted := estimator.EstimateGasWanted(tx)
+	// Prepare the gas fee, for the final gas wanted
+	gasWanted := estimator.EstimateGasWanted(ctx, tx)
+	gasFee := estimator.EstimateGasFee(gasWanted)
 
 	tx.Fee = std.NewFee(gasWanted, gasFee)
 
+	// Drop the placeholder signature
+	tx.Signatures = nil
+
 	return tx
 }
@@ -1,8 +1,10 @@
 package faucet
 
 import (
+	"context"
 	"testing"
 
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -21,14 +23,21 @@ func TestPrepareTransaction(t *testing.T) {
 		expectedGasFee    = std.NewCoin("gnot", 1)
 		expectedGasWanted = int64(100)
 		capturedTx        *std.Tx
+		capturedGasWanted int64
+		capturedSigs      []std.Signature
+
+		signer = &mockPubKey{}
 
 		mockEstimator = &mockEstimator{
-			estimateGasFeeFn: func() std.Coin {
+			estimateGasFeeFn: func(gasWanted int64) std.Coin {
+				capturedGasWanted = gasWanted
+
 				return expectedGasFee
 			},
 
-			estimateGasWantedFn: func(tx *std.Tx) int64 {
+			estimateGasWantedFn: func(_ context.Context, tx *std.Tx) int64 {
 				capturedTx = tx
+				capturedSigs = append(capturedSigs, tx.Signatures...)
 
 				return expectedGasWanted
 			},
@@ -42,7 +51,7 @@ func TestPrepareTransaction(t *testing.T) {
 		SendAmount:  sendAmount,
 	}
 
-	tx := prepareTransaction(mockEstimator, defaultPrepareTxMessage(cfg))
+	tx := prepareTransaction(context.Background(), mockEstimator, signer, "memo", defaultPrepareTxMessage(cfg))
 
 	// Make sure the transaction was created
 	require.NotNil(t, tx)
@@ -50,13 +59,24 @@ func TestPrepareTransaction(t *testing.T) {
 	// Make sure the transaction is unsigned
 	assert.Len(t, tx.Signatures, 0)
 
+	// Make sure the memo is set
+	assert.Equal(t, "memo", tx.Memo)
+
 	// Make sure the transaction fee is correct
 	expectedFee := std.NewFee(expectedGasWanted, expectedGasFee)
 	assert.Equal(t, expectedFee, tx.Fee)
 
-	// Make sure the correct transaction was estimated
+	// Make sure the fee is estimated for the final gas wanted
+	assert.Equal(t, expectedGasWanted, capturedGasWanted)
+
+	// Make sure the correct transaction was estimated,
+	// with the placeholder signer signature
 	assert.Equal(t, tx, capturedTx)
 
+	require.Len(t, capturedSigs, 1)
+	assert.Equal(t, signer, capturedSigs[0].PubKey)
+	assert.Empty(t, capturedSigs[0].Signature)
+
 	// Make sure there is one message
 	require.Len(t, tx.Msgs, 1)
 
@@ -71,3 +91,41 @@ func TestPrepareTransaction(t *testing.T) {
 	assert.Equal(t, toAddress, msgSend.ToAddress)
 	assert.Equal(t, sendAmount, msgSend.Amount)
 }
+
+func TestSpentAmount(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sendAmount = std.NewCoins(std.NewCoin("ugnot", 10))
+
+		t1 = transfer{
+			to:     crypto.Address{1},
+			amount: sendAmount,
+		}
+	)
+
+	t.Run("known messages", func(t *testing.T) {
+		t.Parallel()
+
+		msgs := []std.Msg{
+			bank.MsgSend{Amount: sendAmount},
+			vm.MsgCall{Send: std.NewCoins(std.NewCoin("ugnot", 5))},
+		}
+
+		assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 15)), spentAmount(msgs, t1))
+	})
+
+	t.Run("unknown messages counted once", func(t *testing.T) {
+		t.Parallel()
+
+		msgs := []std.Msg{
+			bank.MsgSend{Amount: sendAmount},
+			vm.MsgAddPackage{},
+			vm.MsgRun{},
+		}
+
+		// The transfer amount is counted once,
+		// regardless of the number of unknown messages
+		assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 20)), spentAmount(msgs, t1))
+	})
+}
@@ -0,0 +1,188 @@
+package faucet
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	errQueueFull   = errors.New("drip queue is full, try again later")
+	errQueueClosed = errors.New("drip queue is closed")
+)
+
+// dripJob is a single unit of work for the drip queue
+type dripJob struct {
+	ctx       context.Context // the drip request context
+	resultCh  chan transferResult
+	transfers []transfer
+}
+
+// dripQueue is a bounded drip work queue, served by a pool of workers.
+// The workers are started with the first submitted job
+type dripQueue struct {
+	executeFn executeTransfersFn
+	jobs      chan *dripJob
+
+	numWorkers int  // the target number of workers
+	running    int  // the number of running workers
+	started    bool // flag indicating if the workers are started
+	poolMux    sync.Mutex
+	workersWg  sync.WaitGroup
+
+	closed bool
+	mux    sync.RWMutex
+}
+
+// newDripQueue creates a new drip queue
+func newDripQueue(numWorkers, maxDepth int, executeFn executeTransfersFn) *dripQueue {
+	return &dripQueue{
+		executeFn:  executeFn,
+		jobs:       make(chan *dripJob, maxDepth),
+		numWorkers: numWorkers,
+	}
+}
+
+// submit enqueues the transfers, and waits for them to be executed [BLOCKING].
+// If the queue is at capacity, the transfers are rejected right away.
+// Jobs whose context is done by the time they are picked up are skipped
+func (q *dripQueue) submit(ctx context.Context, transfers []transfer) (*txResult, error) {
+	job := &dripJob{
+		ctx:       ctx,
+		transfers: transfers,
+		resultCh:  make(chan transferResult, 1),
+	}
+
+	if err := q.enqueue(job); err != nil {
+		return nil, err
+	}
+
+	select {
+	case result := <-job.resultCh:
+		return result.result, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// enqueue adds the job to the queue, without blocking
+func (q *dripQueue) enqueue(job *dripJob) error {
+	q.mux.RLock()
+	defer q.mux.RUnlock()
+
+	if q.closed {
+		return errQueueClosed
+	}
+
+	q.startWorkers()
+
+	select {
+	case q.jobs <- job:
+		return nil
+	default:
+		return errQueueFull
+	}
+}
+
+// startWorkers starts the queue worker pool, if it's not already started
+func (q *dripQueue) startWorkers() {
+	q.poolMux.Lock()
+	defer q.poolMux.Unlock()
+
+	if q.started {
+		return
+	}
+
+	q.started = true
+
+	q.addWorkers()
+}
+
+// resize changes the number of queue workers. When the pool shrinks,
+// the extra workers stop once they execute their next job
+func (q *dripQueue) resize(numWorkers int) {
+	q.mux.RLock()
+	defer q.mux.RUnlock()
+
+	if q.closed {
+		return
+	}
+
+	q.poolMux.Lock()
+	defer q.poolMux.Unlock()
+
+	q.numWorkers = numWorkers
+
+	if q.started {
+		q.addWorkers()
+	}
+}
+
+// addWorkers starts workers until the target number of workers is running.
+// The pool lock needs to be held
+func (q *dripQueue) addWorkers() {
+	for ; q.running < q.numWorkers; q.running++ {
+		q.workersWg.Add(1)
+
+		go q.runWorker()
+	}
+}
+
+// runWorker executes the queued jobs, until the queue
+// is closed, or the worker is no longer needed
+func (q *dripQueue) runWorker() {
+	defer q.workersWg.Done()
+
+	for job := range q.jobs {
+		if err := job.ctx.Err(); err != nil {
+			job.resultCh <- transferResult{
+				err: err,
+			}
+		} else {
+			result, err := q.executeFn(job.ctx, job.transfers)
+
+			job.resultCh <- transferResult{
+				result: result,
+				err:    err,
+			}
+		}
+
+		if q.retireWorker() {
+			return
+		}
+	}
+}
+
+// retireWorker checks if there are more running workers than needed,
+// in which case the calling worker is retired, and needs to stop
+func (q *dripQueue) retireWorker() bool {
+	q.poolMux.Lock()
+	defer q.poolMux.Unlock()
+
+	if q.running <= q.numWorkers {
+		return false
+	}
+
+	q.running--
+
+	return true
+}
+
+// close stops the queue from accepting new jobs,
+// and waits for the already queued jobs to be executed [BLOCKING]
+func (q *dripQueue) close() {
+	q.mux.Lock()
+
+	if q.closed {
+		q.mux.Unlock()
+
+		return
+	}
+
+	q.closed = true
+	close(q.jobs)
+
+	q.mux.Unlock()
+
+	q.workersWg.Wait()
+}
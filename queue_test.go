@@ -0,0 +1,363 @@
+package faucet
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/faucet/spec"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingExecutor is a transfer executor that blocks until released
+type blockingExecutor struct {
+	startedCh chan struct{}
+	releaseCh chan struct{}
+
+	executed int
+	mux      sync.Mutex
+}
+
+func newBlockingExecutor() *blockingExecutor {
+	return &blockingExecutor{
+		startedCh: make(chan struct{}, 10),
+		releaseCh: make(chan struct{}),
+	}
+}
+
+func (e *blockingExecutor) execute(_ context.Context, _ []transfer) (*txResult, error) {
+	e.startedCh <- struct{}{}
+
+	<-e.releaseCh
+
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	e.executed++
+
+	return &txResult{hash: []byte("hash")}, nil
+}
+
+func TestDripQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("job executed", func(t *testing.T) {
+		t.Parallel()
+
+		var captured []transfer
+
+		q := newDripQueue(1, 1, func(_ context.Context, transfers []transfer) (*txResult, error) {
+			captured = transfers
+
+			return &txResult{hash: []byte("hash")}, nil
+		})
+		defer q.close()
+
+		transfers := []transfer{{to: crypto.Address{1}}}
+
+		result, err := q.submit(context.Background(), transfers)
+		require.NoError(t, err)
+
+		assert.Equal(t, []byte("hash"), result.hash)
+		assert.Equal(t, transfers, captured)
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			executor = newBlockingExecutor()
+			wg       sync.WaitGroup
+		)
+
+		q := newDripQueue(1, 1, executor.execute)
+
+		submit := func() {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, err := q.submit(context.Background(), nil)
+				assert.NoError(t, err)
+			}()
+		}
+
+		// Occupy the single worker
+		submit()
+		<-executor.startedCh
+
+		// Occupy the single queue slot
+		submit()
+
+		require.Eventually(t, func() bool {
+			return len(q.jobs) == 1
+		}, time.Second, time.Millisecond)
+
+		// Make sure the job is rejected
+		_, err := q.submit(context.Background(), nil)
+		assert.ErrorIs(t, err, errQueueFull)
+
+		close(executor.releaseCh)
+		wg.Wait()
+
+		q.close()
+	})
+
+	t.Run("queue drained on close", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			executor = newBlockingExecutor()
+			wg       sync.WaitGroup
+		)
+
+		q := newDripQueue(1, 10, executor.execute)
+
+		// Queue up a few jobs
+		numJobs := 3
+
+		for range numJobs {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, err := q.submit(context.Background(), nil)
+				assert.NoError(t, err)
+			}()
+		}
+
+		require.Eventually(t, func() bool {
+			return len(executor.startedCh) == 1 && len(q.jobs) == numJobs-1
+		}, time.Second, time.Millisecond)
+
+		// Close the queue, and release the worker
+		closedCh := make(chan struct{})
+
+		go func() {
+			defer close(closedCh)
+
+			q.close()
+		}()
+
+		close(executor.releaseCh)
+
+		select {
+		case <-closedCh:
+		case <-time.After(5 * time.Second):
+			t.Fatal("queue drain timeout exceeded")
+		}
+
+		wg.Wait()
+
+		// Make sure all queued jobs were executed
+		assert.Equal(t, numJobs, executor.executed)
+
+		// Make sure no new jobs are accepted
+		_, err := q.submit(context.Background(), nil)
+		assert.ErrorIs(t, err, errQueueClosed)
+	})
+
+	t.Run("pool resized", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			executor = newBlockingExecutor()
+			wg       sync.WaitGroup
+		)
+
+		q := newDripQueue(1, 10, executor.execute)
+
+		submit := func() {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, err := q.submit(context.Background(), nil)
+				assert.NoError(t, err)
+			}()
+		}
+
+		// Occupy the single worker, and queue up another job
+		submit()
+		<-executor.startedCh
+
+		submit()
+
+		require.Eventually(t, func() bool {
+			return len(q.jobs) == 1
+		}, time.Second, time.Millisecond)
+
+		// Grow the pool, and make sure the queued job is picked up
+		q.resize(2)
+
+		select {
+		case <-executor.startedCh:
+		case <-time.After(5 * time.Second):
+			t.Fatal("queued job not picked up")
+		}
+
+		// Shrink the pool, and make sure the extra worker stops
+		q.resize(1)
+
+		close(executor.releaseCh)
+		wg.Wait()
+
+		assert.Eventually(t, func() bool {
+			q.poolMux.Lock()
+			defer q.poolMux.Unlock()
+
+			return q.running == 1
+		}, time.Second, time.Millisecond)
+
+		q.close()
+
+		assert.Equal(t, 2, executor.executed)
+	})
+
+	t.Run("canceled job skipped", func(t *testing.T) {
+		t.Parallel()
+
+		executor := newBlockingExecutor()
+
+		q := newDripQueue(1, 10, executor.execute)
+
+		// Occupy the single worker
+		go func() {
+			_, _ = q.submit(context.Background(), nil)
+		}()
+
+		<-executor.startedCh
+
+		// Queue up a job, and give up on it while it's waiting
+		ctx, cancelFn := context.WithCancel(context.Background())
+
+		errCh := make(chan error, 1)
+
+		go func() {
+			_, err := q.submit(ctx, nil)
+
+			errCh <- err
+		}()
+
+		require.Eventually(t, func() bool {
+			return len(q.jobs) == 1
+		}, time.Second, time.Millisecond)
+
+		cancelFn()
+
+		// Make sure the submitter isn't blocked on the queue
+		select {
+		case err := <-errCh:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(5 * time.Second):
+			t.Fatal("submit not canceled")
+		}
+
+		// Release the worker, and make sure the canceled job is not executed
+		close(executor.releaseCh)
+		q.close()
+
+		assert.Equal(t, 1, executor.executed)
+	})
+}
+
+func TestFaucet_Drip_QueueFull(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sendAmount = std.NewCoins(std.NewCoin("ugnot", 10))
+		startedCh  = make(chan struct{}, 10)
+		releaseCh  = make(chan struct{})
+
+		mockClient = &mockClient{
+			getAccountFn: func(_ context.Context, _ crypto.Address) (std.Account, error) {
+				return &mockAccount{
+					getCoinsFn: func() std.Coins {
+						return std.NewCoins(std.NewCoin("ugnot", 1000))
+					},
+				}, nil
+			},
+			sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+				startedCh <- struct{}{}
+
+				<-releaseCh
+
+				return &coreTypes.ResultBroadcastTxCommit{}, nil
+			},
+		}
+		mockEstimator = &mockEstimator{
+			estimateGasFeeFn: func(_ int64) std.Coin {
+				return std.NewCoin("ugnot", 1)
+			},
+		}
+		mockKeyring = &mockKeyring{
+			getSignerFn: func(_ crypto.Address) keyring.Signer {
+				return &mockSigner{}
+			},
+			getAddressesFn: func() []crypto.Address {
+				return []crypto.Address{{0}}
+			},
+		}
+	)
+
+	cfg := config.DefaultConfig()
+	cfg.MaxSendAmount = sendAmount.String()
+	cfg.QueueWorkers = 1
+	cfg.MaxQueueDepth = 1
+
+	f, err := NewFaucet(mockEstimator, mockClient, WithConfig(cfg), WithKeyring(mockKeyring))
+	require.NoError(t, err)
+
+	drip := func() *spec.BaseJSONResponse {
+		return f.defaultHTTPHandler(
+			context.Background(),
+			spec.NewJSONRequest(0, DefaultDripMethod, []any{crypto.Address{1}.String()}),
+		)
+	}
+
+	var wg sync.WaitGroup
+
+	// Occupy the worker
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		assert.Nil(t, drip().Error)
+	}()
+
+	<-startedCh
+
+	// Occupy the queue slot
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		assert.Nil(t, drip().Error)
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(f.queue.jobs) == 1
+	}, time.Second, time.Millisecond)
+
+	// Make sure the drip is rejected
+	response := drip()
+
+	require.NotNil(t, response.Error)
+	assert.Equal(t, spec.ServerBusyErrorCode, response.Error.Code)
+	assert.Contains(t, response.Error.Message, errQueueFull.Error())
+
+	close(releaseCh)
+	wg.Wait()
+}
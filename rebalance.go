@@ -0,0 +1,275 @@
+package faucet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var (
+	errTreasuryNotFound  = errors.New("treasury account not found")
+	errTreasuryExhausted = errors.New("treasury account exhausted")
+)
+
+// rebalancer keeps the faucet accounts funded from the treasury account
+type rebalancer struct {
+	lowWaterMark  std.Coins // the balance under which an account is topped up
+	topUpAmount   std.Coins // the amount an account is topped up with
+	treasuryIndex uint64    // the index of the treasury in the keyring
+
+	exhausted       bool      // flag indicating if the treasury can't cover the top-ups
+	treasuryBalance std.Coins // the latest known treasury balance
+
+	pendingTopUps map[crypto.Address][]byte // account -> top-up tx hash, not yet committed (sync broadcast)
+	mux           sync.RWMutex
+}
+
+// newRebalancer creates a new faucet account rebalancer
+func newRebalancer(treasuryIndex uint64, lowWaterMark, topUpAmount std.Coins) *rebalancer {
+	return &rebalancer{
+		treasuryIndex: treasuryIndex,
+		lowWaterMark:  lowWaterMark,
+		topUpAmount:   topUpAmount,
+		pendingTopUps: make(map[crypto.Address][]byte),
+	}
+}
+
+// setStatus sets the latest treasury status
+func (r *rebalancer) setStatus(exhausted bool, balance std.Coins) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.exhausted = exhausted
+	r.treasuryBalance = balance
+}
+
+// status returns the latest treasury status
+func (r *rebalancer) status() (bool, std.Coins) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	return r.exhausted, r.treasuryBalance
+}
+
+// setPendingTopUp marks the account top-up
+// with the given hash as not yet committed
+func (r *rebalancer) setPendingTopUp(address crypto.Address, hash []byte) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.pendingTopUps[address] = hash
+}
+
+// pendingTopUp returns the hash of the account top-up
+// that is not yet committed, if any
+func (r *rebalancer) pendingTopUp(address crypto.Address) ([]byte, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	hash, exists := r.pendingTopUps[address]
+
+	return hash, exists
+}
+
+// clearPendingTopUp drops the pending account top-up
+func (r *rebalancer) clearPendingTopUp(address crypto.Address) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	delete(r.pendingTopUps, address)
+}
+
+// treasuryAddress returns the address of the treasury account, if any
+func (f *Faucet) treasuryAddress() (crypto.Address, bool) {
+	if f.rebalancer == nil {
+		return crypto.Address{}, false
+	}
+
+	addresses := f.keyring.GetAddresses()
+
+	if f.rebalancer.treasuryIndex >= uint64(len(addresses)) {
+		return crypto.Address{}, false
+	}
+
+	return addresses[f.rebalancer.treasuryIndex], true
+}
+
+// runRebalancer periodically tops up the faucet accounts
+// from the treasury, until the context is done [BLOCKING]
+func (f *Faucet) runRebalancer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.rebalance(ctx); err != nil {
+				f.logger.Error("unable to rebalance faucet accounts", "error", err)
+			}
+		}
+	}
+}
+
+// rebalance tops up the faucet accounts that are under the low-water mark,
+// as a single transaction from the treasury account. If the treasury can't
+// cover all the top-ups, it tops up as many accounts as it can
+func (f *Faucet) rebalance(ctx context.Context) error {
+	// Keep the faucet accounts signing, until the top-ups are done
+	defer f.keyring.acquire()()
+
+	treasury, exists := f.treasuryAddress()
+	if !exists {
+		return errTreasuryNotFound
+	}
+
+	// Fetch the treasury account
+	treasuryAccount, err := f.getAccount(ctx, treasury)
+	if err != nil {
+		return fmt.Errorf("unable to fetch treasury account, %w", err)
+	}
+
+	balance := treasuryAccount.GetCoins()
+
+	// Find the accounts that need to be topped up
+	topUps := make([]transfer, 0)
+
+	for _, address := range f.keyring.GetAddresses() {
+		if address == treasury {
+			continue
+		}
+
+		// The account balance doesn't reflect a pending
+		// top-up, so it would be topped up again
+		if f.isTopUpPending(ctx, address) {
+			continue
+		}
+
+		account, err := f.getAccount(ctx, address)
+		if err != nil {
+			f.logger.Error(
+				"unable to fetch account",
+				"address",
+				address.String(),
+				"error",
+				err,
+			)
+
+			continue
+		}
+
+		if account.GetCoins().IsAllGTE(f.rebalancer.lowWaterMark) {
+			continue
+		}
+
+		topUps = append(topUps, transfer{
+			to:     address,
+			amount: f.rebalancer.topUpAmount,
+		})
+	}
+
+	if len(topUps) == 0 {
+		// The treasury is not exhausted, if nothing needs a top-up
+		f.rebalancer.setStatus(false, balance)
+
+		return nil
+	}
+
+	// Find the top-ups the treasury can cover
+	var (
+		estimatedFee = std.NewCoins(f.estimator.EstimateGasFee(0))
+		covered      = 0
+	)
+
+	for i := range topUps {
+		requiredFunds := totalAmount(topUps[:i+1]).Add(estimatedFee)
+
+		if !balance.IsAllGTE(requiredFunds) {
+			break
+		}
+
+		covered++
+	}
+
+	exhausted := covered < len(topUps)
+	f.rebalancer.setStatus(exhausted, balance)
+
+	if covered > 0 {
+		// Top-ups are always plain transfers,
+		// regardless of the drip message
+		result, err := f.sendTransfers(
+			ctx,
+			treasuryAccount,
+			topUps[:covered],
+			singleMessage(defaultPrepareTxMessage),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to top up faucet accounts, %w", err)
+		}
+
+		for _, topUp := range topUps[:covered] {
+			// With sync broadcasts, the top-up is not yet committed,
+			// so the account is skipped until it is. Otherwise, the
+			// stale cached balance is dropped, so the account is not
+			// topped up again before the next refresh
+			if result.status == DripStatusPending {
+				f.rebalancer.setPendingTopUp(topUp.to, result.hash)
+
+				continue
+			}
+
+			if f.accountCache != nil {
+				f.accountCache.invalidate(topUp.to)
+			}
+		}
+
+		f.logger.Info(
+			"topped up faucet accounts",
+			"treasury",
+			treasury.String(),
+			"accounts",
+			covered,
+		)
+	}
+
+	if exhausted {
+		return fmt.Errorf(
+			"%w, %d accounts not topped up, balance %s",
+			errTreasuryExhausted,
+			len(topUps)-covered,
+			balance.String(),
+		)
+	}
+
+	return nil
+}
+
+// isTopUpPending checks if the account top-up is not yet committed.
+// Once the top-up is committed (or no longer tracked as pending),
+// the account is rebalanced based on its refetched balance
+func (f *Faucet) isTopUpPending(ctx context.Context, address crypto.Address) bool {
+	hash, exists := f.rebalancer.pendingTopUp(address)
+	if !exists {
+		return false
+	}
+
+	status, err := f.getDripStatus(ctx, hash)
+	if err == nil && status.Status == DripStatusPending {
+		return true
+	}
+
+	f.rebalancer.clearPendingTopUp(address)
+
+	// The cached balance can predate the top-up
+	if f.accountCache != nil {
+		f.accountCache.invalidate(address)
+	}
+
+	return false
+}
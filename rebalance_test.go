@@ -0,0 +1,302 @@
+package faucet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gnolang/faucet/config"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRebalancingFaucet creates a faucet with rebalancing
+// enabled, and the given account balances. The first account is the treasury
+func newRebalancingFaucet(
+	t *testing.T,
+	balances []std.Coins,
+	sendFn func(context.Context, *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error),
+) *Faucet {
+	t.Helper()
+
+	addresses := make([]crypto.Address, 0, len(balances))
+	for i := range balances {
+		addresses = append(addresses, crypto.Address{byte(i + 1)})
+	}
+
+	var (
+		mockClient = &mockClient{
+			getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
+				return std.NewBaseAccount(
+					address,
+					balances[address[0]-1],
+					nil,
+					0,
+					0,
+				), nil
+			},
+			sendTransactionCommitFn: sendFn,
+		}
+	)
+
+	cfg := config.DefaultConfig()
+	cfg.NumAccounts = uint64(len(balances))
+	cfg.RebalanceInterval = time.Hour
+	cfg.LowWaterMark = "100ugnot"
+	cfg.TopUpAmount = "500ugnot"
+
+	return newTestFaucet(t, cfg, mockClient, nil, addresses...)
+}
+
+func TestFaucet_Rebalance(t *testing.T) {
+	t.Parallel()
+
+	t.Run("accounts topped up", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			capturedTxs []*std.Tx
+
+			balances = []std.Coins{
+				std.NewCoins(std.NewCoin("ugnot", 10000)), // treasury
+				std.NewCoins(std.NewCoin("ugnot", 50)),    // under the low-water mark
+				std.NewCoins(std.NewCoin("ugnot", 1000)),  // funded
+			}
+		)
+
+		f := newRebalancingFaucet(
+			t,
+			balances,
+			func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+				capturedTxs = append(capturedTxs, tx)
+
+				return &coreTypes.ResultBroadcastTxCommit{}, nil
+			},
+		)
+
+		require.NoError(t, f.rebalance(context.Background()))
+
+		// Make sure only the drained account was topped up
+		require.Len(t, capturedTxs, 1)
+		require.Len(t, capturedTxs[0].Msgs, 1)
+
+		msg, ok := capturedTxs[0].Msgs[0].(bank.MsgSend)
+		require.True(t, ok)
+
+		assert.Equal(t, crypto.Address{1}, msg.FromAddress)
+		assert.Equal(t, crypto.Address{2}, msg.ToAddress)
+		assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 500)), msg.Amount)
+
+		exhausted, _ := f.rebalancer.status()
+		assert.False(t, exhausted)
+	})
+
+	t.Run("treasury exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			capturedTxs []*std.Tx
+
+			balances = []std.Coins{
+				std.NewCoins(std.NewCoin("ugnot", 600)), // treasury, covers a single top-up
+				std.NewCoins(std.NewCoin("ugnot", 50)),  // under the low-water mark
+				std.NewCoins(std.NewCoin("ugnot", 50)),  // under the low-water mark
+			}
+		)
+
+		f := newRebalancingFaucet(
+			t,
+			balances,
+			func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+				capturedTxs = append(capturedTxs, tx)
+
+				return &coreTypes.ResultBroadcastTxCommit{}, nil
+			},
+		)
+
+		assert.ErrorIs(t, f.rebalance(context.Background()), errTreasuryExhausted)
+
+		// Make sure the covered top-up was still sent
+		require.Len(t, capturedTxs, 1)
+		assert.Len(t, capturedTxs[0].Msgs, 1)
+
+		// Make sure the exhaustion is reported
+		exhausted, balance := f.rebalancer.status()
+		assert.True(t, exhausted)
+		assert.Equal(t, balances[0], balance)
+
+		recorder := httptest.NewRecorder()
+		f.healthcheckHandler(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+		var resp response
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(&resp))
+
+		assert.Equal(t, "treasury account exhausted", resp.Message)
+		assert.Equal(t, balances[0].String(), resp.Info["balance"])
+	})
+
+	t.Run("exhaustion cleared once refilled", func(t *testing.T) {
+		t.Parallel()
+
+		balances := []std.Coins{
+			std.NewCoins(std.NewCoin("ugnot", 100)), // treasury, can't cover a top-up
+			std.NewCoins(std.NewCoin("ugnot", 50)),  // under the low-water mark
+		}
+
+		f := newRebalancingFaucet(
+			t,
+			balances,
+			func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+				return &coreTypes.ResultBroadcastTxCommit{}, nil
+			},
+		)
+
+		assert.ErrorIs(t, f.rebalance(context.Background()), errTreasuryExhausted)
+
+		exhausted, _ := f.rebalancer.status()
+		require.True(t, exhausted)
+
+		// Refill the treasury, and fund the drained account elsewhere
+		balances[0] = std.NewCoins(std.NewCoin("ugnot", 10000))
+		balances[1] = std.NewCoins(std.NewCoin("ugnot", 1000))
+
+		require.NoError(t, f.rebalance(context.Background()))
+
+		// Make sure the exhaustion is cleared, with no top-ups needed
+		exhausted, balance := f.rebalancer.status()
+		assert.False(t, exhausted)
+		assert.Equal(t, balances[0], balance)
+
+		recorder := httptest.NewRecorder()
+		f.healthcheckHandler(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("topped up accounts refetched with caching", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			capturedTxs []*std.Tx
+
+			balances = []std.Coins{
+				std.NewCoins(std.NewCoin("ugnot", 10000)), // treasury
+				std.NewCoins(std.NewCoin("ugnot", 50)),    // under the low-water mark
+			}
+		)
+
+		f := newRebalancingFaucet(
+			t,
+			balances,
+			func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+				capturedTxs = append(capturedTxs, tx)
+
+				// Apply the top-ups on chain
+				for _, msg := range tx.Msgs {
+					send, ok := msg.(bank.MsgSend)
+					require.True(t, ok)
+
+					balances[send.FromAddress[0]-1] = balances[send.FromAddress[0]-1].Sub(send.Amount)
+					balances[send.ToAddress[0]-1] = balances[send.ToAddress[0]-1].Add(send.Amount)
+				}
+
+				return &coreTypes.ResultBroadcastTxCommit{}, nil
+			},
+		)
+
+		// Enable account caching, without the periodic refresh
+		f.accountCache = newAccountCache()
+
+		for range 3 {
+			require.NoError(t, f.rebalance(context.Background()))
+		}
+
+		// Make sure the account was topped up only once
+		require.Len(t, capturedTxs, 1)
+		assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 550)), balances[1])
+	})
+
+	t.Run("pending top-ups skipped with sync broadcasts", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			syncTxs   []*std.Tx
+			committed bool
+
+			hash     = []byte("top-up hash")
+			balances = []std.Coins{
+				std.NewCoins(std.NewCoin("ugnot", 10000)), // treasury
+				std.NewCoins(std.NewCoin("ugnot", 50)),    // under the low-water mark
+			}
+		)
+
+		f := newRebalancingFaucet(t, balances, nil)
+
+		f.config.BroadcastMode = config.BroadcastModeSync
+		f.client = &mockClient{
+			getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
+				return std.NewBaseAccount(address, balances[address[0]-1], nil, 0, 0), nil
+			},
+			sendTransactionSyncFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+				syncTxs = append(syncTxs, tx)
+
+				return &coreTypes.ResultBroadcastTx{Hash: hash}, nil
+			},
+			getTransactionFn: func(_ context.Context, _ []byte) (*coreTypes.ResultTx, error) {
+				if !committed {
+					return nil, errors.New("transaction not found")
+				}
+
+				return &coreTypes.ResultTx{Hash: hash}, nil
+			},
+		}
+
+		// Make sure the account is not topped up
+		// again, while the top-up is not committed
+		for range 3 {
+			require.NoError(t, f.rebalance(context.Background()))
+		}
+
+		require.Len(t, syncTxs, 1)
+
+		// Commit the top-up, and make sure the
+		// account is rebalanced on its new balance
+		committed = true
+		balances[1] = balances[1].Add(std.NewCoins(std.NewCoin("ugnot", 500)))
+
+		require.NoError(t, f.rebalance(context.Background()))
+
+		assert.Len(t, syncTxs, 1)
+
+		_, pending := f.rebalancer.pendingTopUp(crypto.Address{2})
+		assert.False(t, pending)
+	})
+
+	t.Run("treasury excluded from drips", func(t *testing.T) {
+		t.Parallel()
+
+		balances := []std.Coins{
+			std.NewCoins(std.NewCoin("ugnot", 10000)), // treasury
+			std.NewCoins(std.NewCoin("ugnot", 1000)),  // funded
+		}
+
+		f := newRebalancingFaucet(t, balances, nil)
+
+		for range 3 {
+			account, err := f.findFundedAccount(context.Background(), std.NewCoins(std.NewCoin("ugnot", 10)))
+			require.NoError(t, err)
+
+			assert.Equal(t, crypto.Address{2}, account.GetAddress())
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package faucet
+
+import (
+	"encoding/base64"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// txResult is the outcome of a broadcast drip transaction
+type txResult struct {
+	status    string         // the drip status (pending or committed)
+	hash      []byte         // the transaction hash
+	from      crypto.Address // the sending faucet account
+	fee       std.Coin       // the transaction gas fee
+	gasWanted int64          // the transaction gas wanted
+	gasUsed   int64          // the transaction gas used, once committed
+	height    int64          // the transaction block height, once committed
+}
+
+// DripResult is the result of a successful drip.
+// In sync broadcast mode, the drip is still pending,
+// so the block height and gas used are not yet known
+type DripResult struct {
+	Status    string `json:"status"`
+	Hash      string `json:"hash"`
+	From      string `json:"from"`
+	Amount    string `json:"amount"`
+	Fee       string `json:"fee"`
+	GasWanted int64  `json:"gasWanted"`
+	GasUsed   int64  `json:"gasUsed,omitempty"`
+	Height    int64  `json:"height,omitempty"`
+}
+
+// newDripResult creates the drip result for the given drip amount.
+// The transaction result can be shared by the drips in a batch
+func newDripResult(result *txResult, amount std.Coins) *DripResult {
+	return &DripResult{
+		Status:    result.status,
+		Hash:      base64.StdEncoding.EncodeToString(result.hash),
+		From:      result.from.String(),
+		Amount:    amount.String(),
+		Fee:       result.fee.String(),
+		GasWanted: result.gasWanted,
+		GasUsed:   result.gasUsed,
+		Height:    result.height,
+	}
+}
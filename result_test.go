@@ -0,0 +1,141 @@
+package faucet
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/spec"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaucet_DripResult(t *testing.T) {
+	t.Parallel()
+
+	var (
+		txHash    = []byte("tx hash")
+		gasFee    = std.NewCoin("ugnot", 5)
+		gasWanted = int64(100000)
+
+		fundAccount = crypto.Address{1}
+	)
+
+	// newResultFaucet creates a faucet that commits
+	// the drip transactions at a fixed height
+	newResultFaucet := func(t *testing.T, cfg *config.Config) *Faucet {
+		t.Helper()
+
+		var (
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
+					return std.NewBaseAccount(
+						address,
+						std.NewCoins(std.NewCoin("ugnot", 1000000000)),
+						nil,
+						0,
+						0,
+					), nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					return &coreTypes.ResultBroadcastTxCommit{
+						DeliverTx: abci.ResponseDeliverTx{
+							GasWanted: gasWanted,
+							GasUsed:   60000,
+						},
+						Hash:   txHash,
+						Height: 10,
+					}, nil
+				},
+			}
+			mockEstimator = &mockEstimator{
+				estimateGasFeeFn: func(_ int64) std.Coin {
+					return gasFee
+				},
+				estimateGasWantedFn: func(_ context.Context, _ *std.Tx) int64 {
+					return gasWanted
+				},
+			}
+		)
+
+		return newTestFaucet(t, cfg, mockClient, mockEstimator, fundAccount)
+	}
+
+	drip := func(f *Faucet, amount string) *spec.BaseJSONResponse {
+		return f.defaultHTTPHandler(
+			context.Background(),
+			spec.NewJSONRequest(
+				0,
+				DefaultDripMethod,
+				[]any{crypto.Address{2}.String(), amount},
+			),
+		)
+	}
+
+	t.Run("committed drip", func(t *testing.T) {
+		t.Parallel()
+
+		f := newResultFaucet(t, config.DefaultConfig())
+
+		response := drip(f, "100ugnot")
+		require.Nil(t, response.Error)
+
+		assert.Equal(t, &DripResult{
+			Status:    DripStatusCommitted,
+			Hash:      base64.StdEncoding.EncodeToString(txHash),
+			From:      fundAccount.String(),
+			Amount:    "100ugnot",
+			Fee:       gasFee.String(),
+			GasWanted: gasWanted,
+			GasUsed:   60000,
+			Height:    10,
+		}, response.Result)
+	})
+
+	t.Run("batched drips", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.DefaultConfig()
+		cfg.BatchWindow = 50 * time.Millisecond
+
+		f := newResultFaucet(t, cfg)
+
+		var (
+			wg sync.WaitGroup
+
+			amounts   = []string{"100ugnot", "200ugnot"}
+			responses = make([]*spec.BaseJSONResponse, len(amounts))
+		)
+
+		for i, amount := range amounts {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				responses[i] = drip(f, amount)
+			}()
+		}
+
+		wg.Wait()
+
+		// Make sure each drip reports its own amount,
+		// as part of the same transaction
+		for i, response := range responses {
+			require.Nil(t, response.Error)
+
+			result, ok := response.Result.(*DripResult)
+			require.True(t, ok)
+
+			assert.Equal(t, amounts[i], result.Amount)
+			assert.Equal(t, base64.StdEncoding.EncodeToString(txHash), result.Hash)
+		}
+	})
+}
@@ -0,0 +1,275 @@
+package faucet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var errNoKeyringAccounts = errors.New("no accounts in the keyring")
+
+// keyringGeneration is a faucet keyring,
+// along with the drips in flight that use it
+type keyringGeneration struct {
+	keyring  keyring.Keyring
+	inflight sync.WaitGroup
+}
+
+// rotatingKeyring is a concurrency-safe faucet keyring, that can be replaced
+// at runtime. New drips only use the active keyring accounts, while the
+// retired keyrings keep signing for the drips in flight, until they drain
+type rotatingKeyring struct {
+	active  *keyringGeneration   // the keyring serving new drips
+	retired []*keyringGeneration // the keyrings draining their drips in flight
+	mux     sync.RWMutex
+}
+
+// newRotatingKeyring creates a new rotating keyring, with the given active keyring
+func newRotatingKeyring(k keyring.Keyring) *rotatingKeyring {
+	return &rotatingKeyring{
+		active: &keyringGeneration{
+			keyring: k,
+		},
+	}
+}
+
+// GetAddresses fetches the addresses of the active keyring
+func (r *rotatingKeyring) GetAddresses() []crypto.Address {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	return r.active.keyring.GetAddresses()
+}
+
+// GetSigner fetches the signer associated with the specified address,
+// from the active keyring, or the retired keyrings that are still draining
+func (r *rotatingKeyring) GetSigner(address crypto.Address) keyring.Signer {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	if signer := r.active.keyring.GetSigner(address); signer != nil {
+		return signer
+	}
+
+	for _, generation := range r.retired {
+		if signer := generation.keyring.GetSigner(address); signer != nil {
+			return signer
+		}
+	}
+
+	return nil
+}
+
+// acquire marks a drip in flight on the active keyring, so the keyring
+// keeps signing for the drip if it's rotated. The returned release
+// function needs to be called once the drip is done
+func (r *rotatingKeyring) acquire() func() {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	generation := r.active
+	generation.inflight.Add(1)
+
+	return generation.inflight.Done
+}
+
+// rotate replaces the active keyring, and returns the retired one.
+// The retired keyring keeps signing until it's removed
+func (r *rotatingKeyring) rotate(k keyring.Keyring) *keyringGeneration {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	retired := r.active
+
+	r.retired = append(r.retired, retired)
+	r.active = &keyringGeneration{
+		keyring: k,
+	}
+
+	return retired
+}
+
+// remove drops the retired keyring
+func (r *rotatingKeyring) remove(generation *keyringGeneration) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	for i, retired := range r.retired {
+		if retired == generation {
+			r.retired = append(r.retired[:i], r.retired[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// drain waits for the drips in flight on the keyring to finish,
+// or for the context to be done
+func (g *keyringGeneration) drain(ctx context.Context) error {
+	drained := make(chan struct{})
+
+	go func() {
+		defer close(drained)
+
+		g.inflight.Wait()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-drained:
+		return nil
+	}
+}
+
+// RotateKeyring replaces the faucet keyring at runtime. New drips are
+// served by the new keyring accounts right away, while the drips in flight
+// on the old accounts are drained before the old keyring is removed.
+// If sweep is set, the leftover balances of the old accounts are then
+// transferred to the new accounts. If the context is done before the
+// old accounts drain, the old keyring is dropped once it drains, unswept
+func (f *Faucet) RotateKeyring(ctx context.Context, k keyring.Keyring, sweep bool) error {
+	// Keep the multisig accounts, on top of the new member keys
+	k, err := withMultisigAccounts(k, f.config.MultisigAccounts)
+	if err != nil {
+		return fmt.Errorf("unable to set up multisig accounts, %w", err)
+	}
+
+	if len(k.GetAddresses()) == 0 {
+		return errNoKeyringAccounts
+	}
+
+	retired := f.keyring.rotate(k)
+
+	// Keep a worker for each faucet account, unless set explicitly
+	if f.config.QueueWorkers == 0 {
+		f.queue.resize(len(k.GetAddresses()))
+	}
+
+	f.logger.Info(
+		"faucet keyring rotated, draining the old accounts",
+		"accounts",
+		len(k.GetAddresses()),
+	)
+
+	// Wait for the drips in flight on the old accounts
+	if err = retired.drain(ctx); err != nil {
+		// Drop the old keyring once it drains on its own
+		go func() {
+			retired.inflight.Wait()
+			f.keyring.remove(retired)
+			f.dropAccounts(retired.keyring.GetAddresses())
+		}()
+
+		return fmt.Errorf("unable to drain old accounts, %w", err)
+	}
+
+	defer f.keyring.remove(retired)
+
+	if sweep {
+		f.sweepAccounts(ctx, retired.keyring.GetAddresses(), k.GetAddresses())
+	}
+
+	// Drop the local state of the old accounts
+	f.dropAccounts(retired.keyring.GetAddresses())
+
+	return nil
+}
+
+// dropAccounts drops the local sequences, cached balances and pending
+// top-ups of the old accounts that are no longer faucet accounts
+func (f *Faucet) dropAccounts(addresses []crypto.Address) {
+	active := f.keyring.GetAddresses()
+
+	for _, address := range addresses {
+		if slices.Contains(active, address) {
+			// The account is still a faucet account
+			continue
+		}
+
+		f.sequencer.remove(address)
+
+		if f.accountCache != nil {
+			f.accountCache.invalidate(address)
+		}
+
+		if f.rebalancer != nil {
+			f.rebalancer.clearPendingTopUp(address)
+		}
+	}
+}
+
+// sweepAccounts transfers the leftover balances of the old accounts to the
+// new accounts (spread evenly), keeping only the transfer fee. Sweep failures
+// are logged, since the old accounts can always be swept manually
+func (f *Faucet) sweepAccounts(ctx context.Context, from, to []crypto.Address) {
+	fee := std.NewCoins(f.estimator.EstimateGasFee(0))
+
+	for i, address := range from {
+		if slices.Contains(to, address) {
+			// The account is still a faucet account
+			continue
+		}
+
+		account, err := f.getAccount(ctx, address)
+		if err != nil {
+			f.logger.Error(
+				"unable to fetch old account",
+				"address",
+				address.String(),
+				"error",
+				err,
+			)
+
+			continue
+		}
+
+		balance := account.GetCoins()
+		if !balance.IsAllGTE(fee) {
+			continue
+		}
+
+		leftover := balance.Sub(fee)
+		if leftover.IsZero() {
+			continue
+		}
+
+		sweep := transfer{
+			to:     to[i%len(to)],
+			amount: leftover,
+		}
+
+		if _, err := f.sendTransfers(
+			ctx,
+			account,
+			[]transfer{sweep},
+			singleMessage(defaultPrepareTxMessage),
+		); err != nil {
+			f.logger.Error(
+				"unable to sweep old account",
+				"address",
+				address.String(),
+				"error",
+				err,
+			)
+
+			continue
+		}
+
+		f.logger.Info(
+			"old account swept",
+			"address",
+			address.String(),
+			"to",
+			sweep.to.String(),
+			"amount",
+			leftover.String(),
+		)
+	}
+}
@@ -0,0 +1,233 @@
+package faucet
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnolang/faucet/config"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingKeyring(t *testing.T) {
+	t.Parallel()
+
+	var (
+		oldAddress = crypto.Address{1}
+		newAddress = crypto.Address{2}
+
+		r = newRotatingKeyring(newTestKeyring(oldAddress))
+	)
+
+	retired := r.rotate(newTestKeyring(newAddress))
+
+	// Make sure only the new accounts serve new drips
+	assert.Equal(t, []crypto.Address{newAddress}, r.GetAddresses())
+
+	// Make sure the old accounts still sign, until removed
+	assert.NotNil(t, r.GetSigner(newAddress))
+	assert.NotNil(t, r.GetSigner(oldAddress))
+
+	r.remove(retired)
+
+	assert.Nil(t, r.GetSigner(oldAddress))
+	assert.NotNil(t, r.GetSigner(newAddress))
+}
+
+func TestFaucet_RotateKeyring(t *testing.T) {
+	t.Parallel()
+
+	var (
+		oldAddress = crypto.Address{1}
+		newAddress = crypto.Address{2}
+	)
+
+	t.Run("empty keyring", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := NewFaucet(
+			&mockEstimator{},
+			&mockClient{},
+			WithConfig(config.DefaultConfig()),
+			WithKeyring(newTestKeyring(oldAddress)),
+		)
+		require.NoError(t, err)
+
+		assert.ErrorIs(
+			t,
+			f.RotateKeyring(context.Background(), newTestKeyring(), false),
+			errNoKeyringAccounts,
+		)
+
+		// Make sure the keyring was not rotated
+		assert.Equal(t, []crypto.Address{oldAddress}, f.keyring.GetAddresses())
+	})
+
+	t.Run("in-flight drips drained", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := NewFaucet(
+			&mockEstimator{},
+			&mockClient{},
+			WithConfig(config.DefaultConfig()),
+			WithKeyring(newTestKeyring(oldAddress)),
+		)
+		require.NoError(t, err)
+
+		// Start a drip on the old accounts
+		release := f.keyring.acquire()
+
+		rotated := make(chan error, 1)
+
+		go func() {
+			rotated <- f.RotateKeyring(context.Background(), newTestKeyring(newAddress), false)
+		}()
+
+		// Make sure new drips use the new accounts right away
+		require.Eventually(t, func() bool {
+			addresses := f.keyring.GetAddresses()
+
+			return len(addresses) == 1 && addresses[0] == newAddress
+		}, time.Second, 5*time.Millisecond)
+
+		// Make sure the old accounts keep signing for the drip in flight
+		select {
+		case <-rotated:
+			t.Fatal("rotation did not wait for the drip in flight")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		assert.NotNil(t, f.keyring.GetSigner(oldAddress))
+
+		// Finish the drip, and make sure the old accounts are removed
+		release()
+
+		select {
+		case err := <-rotated:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("rotation did not finish")
+		}
+
+		assert.Nil(t, f.keyring.GetSigner(oldAddress))
+	})
+
+	t.Run("drain canceled", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := NewFaucet(
+			&mockEstimator{},
+			&mockClient{},
+			WithConfig(config.DefaultConfig()),
+			WithKeyring(newTestKeyring(oldAddress)),
+		)
+		require.NoError(t, err)
+
+		release := f.keyring.acquire()
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancelFn()
+
+		assert.ErrorIs(
+			t,
+			f.RotateKeyring(ctx, newTestKeyring(newAddress), false),
+			context.DeadlineExceeded,
+		)
+
+		// Make sure the old accounts are dropped once drained
+		assert.NotNil(t, f.keyring.GetSigner(oldAddress))
+
+		release()
+
+		assert.Eventually(t, func() bool {
+			return f.keyring.GetSigner(oldAddress) == nil
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("old accounts swept", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			capturedTxs []*std.Tx
+			mux         sync.Mutex
+
+			fee     = std.NewCoin("ugnot", 10)
+			balance = std.NewCoins(std.NewCoin("ugnot", 1000), std.NewCoin("utest", 5))
+
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
+					return std.NewBaseAccount(address, balance, nil, 0, 0), nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					mux.Lock()
+					defer mux.Unlock()
+
+					capturedTxs = append(capturedTxs, tx)
+
+					return &coreTypes.ResultBroadcastTxCommit{}, nil
+				},
+			}
+			mockEstimator = &mockEstimator{
+				estimateGasFeeFn: func(_ int64) std.Coin {
+					return fee
+				},
+			}
+		)
+
+		f, err := NewFaucet(
+			mockEstimator,
+			mockClient,
+			WithConfig(config.DefaultConfig()),
+			WithKeyring(newTestKeyring(oldAddress)),
+		)
+		require.NoError(t, err)
+
+		require.NoError(t, f.RotateKeyring(context.Background(), newTestKeyring(newAddress), true))
+
+		// Make sure the leftover balance was swept to the new account
+		require.Len(t, capturedTxs, 1)
+		require.Len(t, capturedTxs[0].Msgs, 1)
+
+		msg, ok := capturedTxs[0].Msgs[0].(bank.MsgSend)
+		require.True(t, ok)
+
+		assert.Equal(t, oldAddress, msg.FromAddress)
+		assert.Equal(t, newAddress, msg.ToAddress)
+		assert.Equal(t, balance.Sub(std.NewCoins(fee)), msg.Amount)
+	})
+	t.Run("old account state dropped", func(t *testing.T) {
+		t.Parallel()
+
+		keptAddress := crypto.Address{3}
+
+		f, err := NewFaucet(
+			&mockEstimator{},
+			&mockClient{},
+			WithConfig(config.DefaultConfig()),
+			WithKeyring(newTestKeyring(oldAddress, keptAddress)),
+		)
+		require.NoError(t, err)
+
+		for _, address := range []crypto.Address{oldAddress, keptAddress} {
+			f.sequencer.lock(address).unlock()
+		}
+
+		require.NoError(
+			t,
+			f.RotateKeyring(context.Background(), newTestKeyring(newAddress, keptAddress, crypto.Address{4}), false),
+		)
+
+		// Make sure only the retired account sequences are dropped
+		assert.NotContains(t, f.sequencer.accounts, oldAddress)
+		assert.Contains(t, f.sequencer.accounts, keptAddress)
+
+		// Make sure the queue workers follow the new accounts
+		assert.Equal(t, 3, f.queue.numWorkers)
+	})
+}
@@ -0,0 +1,40 @@
+package balance
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Selector is a highest-balance account selector
+// (picks the funded account with the most funds in the drip denominations)
+type Selector struct{}
+
+// New creates a new highest-balance account selector
+func New() *Selector {
+	return &Selector{}
+}
+
+func (s *Selector) Select(accounts []std.Account, amount std.Coins) std.Account {
+	selected := accounts[0]
+
+	for _, account := range accounts[1:] {
+		if hasHigherBalance(account.GetCoins(), selected.GetCoins(), amount) {
+			selected = account
+		}
+	}
+
+	return selected
+}
+
+// hasHigherBalance checks if balance a is higher than balance b,
+// by comparing the amounts of the drip denominations in order
+func hasHigherBalance(a, b, amount std.Coins) bool {
+	for _, coin := range amount {
+		aAmount, bAmount := a.AmountOf(coin.Denom), b.AmountOf(coin.Denom)
+
+		if aAmount != bAmount {
+			return aAmount > bAmount
+		}
+	}
+
+	return false
+}
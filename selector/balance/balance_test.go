@@ -0,0 +1,44 @@
+package balance
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelector_Select(t *testing.T) {
+	t.Parallel()
+
+	var (
+		poor = &std.BaseAccount{
+			Address: crypto.Address{1},
+			Coins:   std.MustParseCoins("100ugnot"),
+		}
+		rich = &std.BaseAccount{
+			Address: crypto.Address{2},
+			Coins:   std.MustParseCoins("1000ugnot"),
+		}
+		richOtherDenom = &std.BaseAccount{
+			Address: crypto.Address{3},
+			Coins:   std.MustParseCoins("10ugnot,5000utest"),
+		}
+
+		accounts = []std.Account{poor, rich, richOtherDenom}
+	)
+
+	s := New()
+
+	assert.Equal(
+		t,
+		rich.Address,
+		s.Select(accounts, std.MustParseCoins("10ugnot")).GetAddress(),
+	)
+
+	assert.Equal(
+		t,
+		richOtherDenom.Address,
+		s.Select(accounts, std.MustParseCoins("10utest")).GetAddress(),
+	)
+}
@@ -0,0 +1,45 @@
+package lru
+
+import (
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Selector is a least-recently-used account selector
+// (picks the funded account that has been idle the longest)
+type Selector struct {
+	lastUsed map[crypto.Address]uint64 // address -> selection tick
+	tick     uint64
+
+	mux sync.Mutex
+}
+
+// New creates a new least-recently-used account selector
+func New() *Selector {
+	return &Selector{
+		lastUsed: make(map[crypto.Address]uint64),
+	}
+}
+
+func (s *Selector) Select(accounts []std.Account, _ std.Coins) std.Account {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	// Find the account with the oldest selection tick.
+	// Accounts that were never selected have a tick of 0
+	selected := accounts[0]
+
+	for _, account := range accounts[1:] {
+		if s.lastUsed[account.GetAddress()] < s.lastUsed[selected.GetAddress()] {
+			selected = account
+		}
+	}
+
+	// Mark the account as used
+	s.tick++
+	s.lastUsed[selected.GetAddress()] = s.tick
+
+	return selected
+}
@@ -0,0 +1,34 @@
+package lru
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelector_Select(t *testing.T) {
+	t.Parallel()
+
+	var (
+		first  = &std.BaseAccount{Address: crypto.Address{1}}
+		second = &std.BaseAccount{Address: crypto.Address{2}}
+		third  = &std.BaseAccount{Address: crypto.Address{3}}
+	)
+
+	s := New()
+
+	// Unused accounts are selected first
+	assert.Equal(t, first.Address, s.Select([]std.Account{first, second}, std.Coins{}).GetAddress())
+	assert.Equal(t, second.Address, s.Select([]std.Account{first, second}, std.Coins{}).GetAddress())
+
+	// The third account was never used
+	assert.Equal(t, third.Address, s.Select([]std.Account{first, second, third}, std.Coins{}).GetAddress())
+
+	// The first account is now the least recently used
+	assert.Equal(t, first.Address, s.Select([]std.Account{first, second, third}, std.Coins{}).GetAddress())
+
+	// The least recently used account is not funded
+	assert.Equal(t, third.Address, s.Select([]std.Account{first, third}, std.Coins{}).GetAddress())
+}
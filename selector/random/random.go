@@ -0,0 +1,21 @@
+package random
+
+import (
+	"math/rand/v2"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Selector is a random account selector
+// (picks any of the funded accounts, uniformly)
+type Selector struct{}
+
+// New creates a new random account selector
+func New() *Selector {
+	return &Selector{}
+}
+
+func (s *Selector) Select(accounts []std.Account, _ std.Coins) std.Account {
+	//nolint:gosec // account selection doesn't need a cryptographically secure source
+	return accounts[rand.IntN(len(accounts))]
+}
@@ -0,0 +1,25 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelector_Select(t *testing.T) {
+	t.Parallel()
+
+	accounts := []std.Account{
+		&std.BaseAccount{Address: crypto.Address{1}},
+		&std.BaseAccount{Address: crypto.Address{2}},
+	}
+
+	s := New()
+
+	// Make sure only the given accounts are selected
+	for range 10 {
+		assert.Contains(t, accounts, s.Select(accounts, std.Coins{}))
+	}
+}
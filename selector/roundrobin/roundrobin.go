@@ -0,0 +1,24 @@
+package roundrobin
+
+import (
+	"sync/atomic"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Selector is a round-robin account selector
+// (cycles through the funded accounts)
+type Selector struct {
+	counter atomic.Uint64
+}
+
+// New creates a new round-robin account selector
+func New() *Selector {
+	return &Selector{}
+}
+
+func (s *Selector) Select(accounts []std.Account, _ std.Coins) std.Account {
+	index := (s.counter.Add(1) - 1) % uint64(len(accounts))
+
+	return accounts[index]
+}
@@ -0,0 +1,28 @@
+package roundrobin
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelector_Select(t *testing.T) {
+	t.Parallel()
+
+	accounts := []std.Account{
+		&std.BaseAccount{Address: crypto.Address{1}},
+		&std.BaseAccount{Address: crypto.Address{2}},
+		&std.BaseAccount{Address: crypto.Address{3}},
+	}
+
+	s := New()
+
+	// Make sure the accounts are cycled through
+	for i := range 2 * len(accounts) {
+		selected := s.Select(accounts, std.Coins{})
+
+		assert.Equal(t, accounts[i%len(accounts)].GetAddress(), selected.GetAddress())
+	}
+}
@@ -0,0 +1,10 @@
+package selector
+
+import "github.com/gnolang/gno/tm2/pkg/std"
+
+// Selector defines the faucet account selection strategy
+type Selector interface {
+	// Select picks the account that should serve the drip amount,
+	// out of the (non-empty) set of funded faucet accounts
+	Select(accounts []std.Account, amount std.Coins) std.Account
+}
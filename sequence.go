@@ -0,0 +1,96 @@
+package faucet
+
+import (
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+// sequencer keeps track of faucet account sequences locally,
+// so concurrent drips served from the same account
+// don't end up signing with the same sequence
+type sequencer struct {
+	accounts map[crypto.Address]*accountSequence // address -> local sequence
+	mux      sync.Mutex
+}
+
+// accountSequence is the local sequence state of a single faucet account.
+// Its lock needs to be held for the entire sign + broadcast flow
+type accountSequence struct {
+	sequence uint64 // the next sequence to be used
+	synced   bool   // flag indicating if the local sequence is initialized
+
+	mux sync.Mutex
+}
+
+// newSequencer creates a new faucet account sequencer
+func newSequencer() *sequencer {
+	return &sequencer{
+		accounts: make(map[crypto.Address]*accountSequence),
+	}
+}
+
+// lock acquires the signing lock for the given account,
+// and returns its local sequence state. The caller needs to
+// release the lock with unlock, once the transaction is broadcast
+func (s *sequencer) lock(address crypto.Address) *accountSequence {
+	s.mux.Lock()
+
+	account, exists := s.accounts[address]
+	if !exists {
+		account = &accountSequence{}
+		s.accounts[address] = account
+	}
+
+	s.mux.Unlock()
+
+	account.mux.Lock()
+
+	return account
+}
+
+// remove drops the local sequences of the given accounts,
+// once they no longer serve drips
+func (s *sequencer) remove(addresses ...crypto.Address) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, address := range addresses {
+		delete(s.accounts, address)
+	}
+}
+
+// unlock releases the account signing lock
+func (a *accountSequence) unlock() {
+	a.mux.Unlock()
+}
+
+// next returns the sequence the next transaction should be signed with.
+// The chain sequence is a lower bound, since it reflects
+// all transactions from the account that have already been committed
+func (a *accountSequence) next(chainSequence uint64) uint64 {
+	if !a.synced || chainSequence > a.sequence {
+		a.sequence = chainSequence
+		a.synced = true
+	}
+
+	return a.sequence
+}
+
+// increment marks the current sequence as used
+func (a *accountSequence) increment() {
+	a.sequence++
+}
+
+// sync overwrites the local sequence with the chain sequence
+func (a *accountSequence) sync(chainSequence uint64) {
+	a.sequence = chainSequence
+	a.synced = true
+}
+
+// reset drops the local sequence, so the next
+// transaction relies on the chain sequence
+func (a *accountSequence) reset() {
+	a.sequence = 0
+	a.synced = false
+}
@@ -0,0 +1,104 @@
+package faucet
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequencer_Next(t *testing.T) {
+	t.Parallel()
+
+	t.Run("initial sequence from chain", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSequencer()
+
+		accountSequence := s.lock(crypto.Address{1})
+		defer accountSequence.unlock()
+
+		assert.Equal(t, uint64(10), accountSequence.next(10))
+	})
+
+	t.Run("local sequence ahead of chain", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSequencer()
+
+		accountSequence := s.lock(crypto.Address{1})
+		defer accountSequence.unlock()
+
+		assert.Equal(t, uint64(10), accountSequence.next(10))
+		accountSequence.increment()
+
+		// The chain hasn't caught up yet
+		assert.Equal(t, uint64(11), accountSequence.next(10))
+	})
+
+	t.Run("chain sequence ahead of local", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSequencer()
+
+		accountSequence := s.lock(crypto.Address{1})
+		defer accountSequence.unlock()
+
+		assert.Equal(t, uint64(10), accountSequence.next(10))
+		accountSequence.increment()
+
+		// The account was used outside the faucet
+		assert.Equal(t, uint64(20), accountSequence.next(20))
+	})
+
+	t.Run("sync and reset", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSequencer()
+
+		accountSequence := s.lock(crypto.Address{1})
+		defer accountSequence.unlock()
+
+		accountSequence.sync(5)
+		assert.Equal(t, uint64(5), accountSequence.next(0))
+
+		accountSequence.reset()
+		assert.Equal(t, uint64(0), accountSequence.next(0))
+	})
+
+	t.Run("separate accounts", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSequencer()
+
+		first := s.lock(crypto.Address{1})
+		first.next(0)
+		first.increment()
+		first.unlock()
+
+		second := s.lock(crypto.Address{2})
+		defer second.unlock()
+
+		assert.Equal(t, uint64(0), second.next(0))
+	})
+}
+
+func TestSequencer_Remove(t *testing.T) {
+	t.Parallel()
+
+	s := newSequencer()
+
+	accountSequence := s.lock(crypto.Address{1})
+	accountSequence.sync(10)
+	accountSequence.unlock()
+
+	s.remove(crypto.Address{1})
+
+	assert.NotContains(t, s.accounts, crypto.Address{1})
+
+	// Make sure the account starts over from the chain sequence
+	accountSequence = s.lock(crypto.Address{1})
+	defer accountSequence.unlock()
+
+	assert.Equal(t, uint64(0), accountSequence.next(0))
+}
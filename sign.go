@@ -1,9 +1,10 @@
 package faucet
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/faucet/keyring"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -15,8 +16,8 @@ type signCfg struct {
 }
 
 // signTransaction signs the specified transaction using
-// the provided key and config
-func signTransaction(tx *std.Tx, key crypto.PrivKey, cfg signCfg) error {
+// the provided signer and config, within the context
+func signTransaction(ctx context.Context, tx *std.Tx, signer keyring.Signer, cfg signCfg) error {
 	// Get the sign bytes
 	signBytes, err := tx.GetSignBytes(
 		cfg.chainID,
@@ -28,14 +29,14 @@ func signTransaction(tx *std.Tx, key crypto.PrivKey, cfg signCfg) error {
 	}
 
 	// Sign the transaction
-	signature, err := key.Sign(signBytes)
+	signature, err := signer.Sign(ctx, signBytes)
 	if err != nil {
 		return fmt.Errorf("unable to sign transaction, %w", err)
 	}
 
 	// Save the signature
 	tx.Signatures = append(tx.Signatures, std.Signature{
-		PubKey:    key.PubKey(),
+		PubKey:    signer.PubKey(),
 		Signature: signature,
 	})
 
@@ -1,9 +1,14 @@
 package faucet
 
 import (
+	"context"
 	"errors"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/keyring/memory"
+	"github.com/gnolang/faucet/keyring/remote"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/stretchr/testify/assert"
@@ -29,7 +34,7 @@ func TestSignTransaction(t *testing.T) {
 					return "public key"
 				},
 			}
-			mockPrivKey = &mockPrivKey{
+			mockSigner = &mockSigner{
 				signFn: func(signData []byte) ([]byte, error) {
 					capturedSignData = signData
 
@@ -53,7 +58,7 @@ func TestSignTransaction(t *testing.T) {
 		}
 
 		// Sign the transaction
-		require.NoError(t, signTransaction(tx, mockPrivKey, cfg))
+		require.NoError(t, signTransaction(context.Background(), tx, mockSigner, cfg))
 
 		// Make sure the correct bytes were signed
 		assert.Equal(t, expectedSignBytes, capturedSignData)
@@ -68,6 +73,49 @@ func TestSignTransaction(t *testing.T) {
 		assert.Equal(t, mockPubKey.String(), sig.PubKey.String())
 	})
 
+	t.Run("remote signature", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chainID       = "gno"
+			accountNumber = uint64(1)
+			sequence      = uint64(0)
+
+			local = memory.New(config.DefaultMnemonic, 1)
+		)
+
+		// Start the stand-in remote signer
+		srv := httptest.NewServer(remote.NewServer(local))
+		t.Cleanup(srv.Close)
+
+		kr, err := remote.New(context.Background(), srv.URL)
+		require.NoError(t, err)
+
+		address := local.GetAddresses()[0]
+
+		// Create a dummy tx
+		tx := &std.Tx{}
+		expectedSignBytes, err := tx.GetSignBytes(chainID, accountNumber, sequence)
+		require.NoError(t, err)
+
+		cfg := signCfg{
+			chainID:       chainID,
+			accountNumber: accountNumber,
+			sequence:      sequence,
+		}
+
+		// Sign the transaction, without the private key
+		require.NoError(t, signTransaction(context.Background(), tx, kr.GetSigner(address), cfg))
+
+		// Make sure the signature is valid
+		require.Len(t, tx.Signatures, 1)
+
+		sig := tx.Signatures[0]
+
+		assert.Equal(t, address, sig.PubKey.Address())
+		assert.True(t, sig.PubKey.VerifyBytes(expectedSignBytes, sig.Signature))
+	})
+
 	t.Run("invalid signature", func(t *testing.T) {
 		t.Parallel()
 
@@ -79,7 +127,7 @@ func TestSignTransaction(t *testing.T) {
 			capturedSignData []byte
 			signErr          = errors.New("invalid sign data")
 
-			mockPrivKey = &mockPrivKey{
+			mockSigner = &mockSigner{
 				signFn: func(signData []byte) ([]byte, error) {
 					capturedSignData = signData
 
@@ -100,7 +148,7 @@ func TestSignTransaction(t *testing.T) {
 		}
 
 		// Sign the transaction
-		require.ErrorIs(t, signTransaction(tx, mockPrivKey, cfg), signErr)
+		require.ErrorIs(t, signTransaction(context.Background(), tx, mockSigner, cfg), signErr)
 
 		// Make sure the appropriate bytes were attempted
 		// to be signed
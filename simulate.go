@@ -0,0 +1,51 @@
+package faucet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/faucet/client"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var errSimulationFailed = errors.New("transaction failed simulation")
+
+// SimulationError describes why the transaction
+// would fail, according to the node simulation
+type SimulationError struct {
+	err error // the simulated execution error
+
+	Log       string `json:"log"`       // the simulated execution log
+	GasWanted int64  `json:"gasWanted"` // the transaction gas wanted
+	GasUsed   int64  `json:"gasUsed"`   // the simulated gas used
+}
+
+func (e *SimulationError) Error() string {
+	return fmt.Sprintf("%s, %s", errSimulationFailed.Error(), e.err.Error())
+}
+
+func (e *SimulationError) Unwrap() []error {
+	return []error{errSimulationFailed, e.err}
+}
+
+// simulateTransaction runs the signed transaction through the node's
+// simulation, and returns an error if it would fail once broadcast
+func simulateTransaction(ctx context.Context, client client.Client, tx *std.Tx) error {
+	response, err := client.SimulateTransaction(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("unable to simulate transaction, %w", err)
+	}
+
+	// Check the errors
+	if response.IsErr() {
+		return &SimulationError{
+			err:       response.Error,
+			Log:       response.Log,
+			GasWanted: tx.Fee.GasWanted,
+			GasUsed:   response.GasUsed,
+		}
+	}
+
+	return nil
+}
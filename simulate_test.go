@@ -0,0 +1,226 @@
+package faucet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/spec"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tm2Errors "github.com/gnolang/gno/tm2/pkg/bft/abci/example/errors"
+)
+
+func TestSimulateTransaction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unable to simulate", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			queryErr = errors.New("unable to query")
+
+			mockClient = &mockClient{
+				simulateTransactionFn: func(_ context.Context, _ *std.Tx) (*abci.ResponseDeliverTx, error) {
+					return nil, queryErr
+				},
+			}
+		)
+
+		err := simulateTransaction(context.Background(), mockClient, &std.Tx{})
+		require.ErrorIs(t, err, queryErr)
+
+		var simulationErr *SimulationError
+		assert.False(t, errors.As(err, &simulationErr))
+	})
+
+	t.Run("simulation failed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			capturedTx *std.Tx
+
+			deliverTxErr = tm2Errors.UnauthorizedError{}
+			tx           = &std.Tx{
+				Fee: std.Fee{
+					GasWanted: 100000,
+				},
+			}
+
+			mockClient = &mockClient{
+				simulateTransactionFn: func(_ context.Context, tx *std.Tx) (*abci.ResponseDeliverTx, error) {
+					capturedTx = tx
+
+					return &abci.ResponseDeliverTx{
+						ResponseBase: abci.ResponseBase{
+							Error: deliverTxErr,
+							Log:   "unauthorized",
+						},
+						GasUsed: 50000,
+					}, nil
+				},
+			}
+		)
+
+		err := simulateTransaction(context.Background(), mockClient, tx)
+		require.ErrorIs(t, err, errSimulationFailed)
+		require.ErrorIs(t, err, deliverTxErr)
+
+		// Make sure the simulation details are reported
+		var simulationErr *SimulationError
+		require.True(t, errors.As(err, &simulationErr))
+
+		assert.Equal(t, "unauthorized", simulationErr.Log)
+		assert.Equal(t, int64(100000), simulationErr.GasWanted)
+		assert.Equal(t, int64(50000), simulationErr.GasUsed)
+
+		// Make sure the correct transaction was simulated
+		assert.Equal(t, tx, capturedTx)
+	})
+
+	t.Run("simulation passed", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockClient{
+			simulateTransactionFn: func(_ context.Context, _ *std.Tx) (*abci.ResponseDeliverTx, error) {
+				return &abci.ResponseDeliverTx{}, nil
+			},
+		}
+
+		assert.NoError(t, simulateTransaction(context.Background(), mockClient, &std.Tx{}))
+	})
+}
+
+func TestFaucet_Simulation(t *testing.T) {
+	t.Parallel()
+
+	// newSimulatingFaucet creates a faucet with a single
+	// faucet account, and the given simulation setting
+	newSimulatingFaucet := func(t *testing.T, simulate bool, client *mockClient) *Faucet {
+		t.Helper()
+
+		cfg := config.DefaultConfig()
+		cfg.SimulateTransactions = simulate
+
+		return newTestFaucet(t, cfg, client, nil)
+	}
+
+	drip := func(f *Faucet) *spec.BaseJSONResponse {
+		return f.defaultHTTPHandler(
+			context.Background(),
+			spec.NewJSONRequest(
+				0,
+				DefaultDripMethod,
+				[]any{crypto.Address{2}.String(), "100ugnot"},
+			),
+		)
+	}
+
+	t.Run("failed simulation not broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			broadcasts int
+
+			mockClient = &mockClient{
+				simulateTransactionFn: func(_ context.Context, _ *std.Tx) (*abci.ResponseDeliverTx, error) {
+					return &abci.ResponseDeliverTx{
+						ResponseBase: abci.ResponseBase{
+							Error: tm2Errors.UnauthorizedError{},
+							Log:   "unauthorized",
+						},
+					}, nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					broadcasts++
+
+					return &coreTypes.ResultBroadcastTxCommit{}, nil
+				},
+			}
+		)
+
+		f := newSimulatingFaucet(t, true, mockClient)
+
+		response := drip(f)
+		require.NotNil(t, response.Error)
+
+		// Make sure the simulation details are part of the error
+		assert.Equal(t, spec.ServerErrorCode, response.Error.Code)
+
+		simulationErr, ok := response.Error.Data.(*SimulationError)
+		require.True(t, ok)
+
+		assert.Equal(t, "unauthorized", simulationErr.Log)
+
+		// Make sure the transaction was never broadcast
+		assert.Zero(t, broadcasts)
+
+		// Make sure the unused sequence is reused
+		sequence := f.sequencer.lock(crypto.Address{1})
+		defer sequence.unlock()
+
+		assert.Equal(t, uint64(0), sequence.next(0))
+	})
+
+	t.Run("passed simulation broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			simulations int
+			broadcasts  int
+
+			mockClient = &mockClient{
+				simulateTransactionFn: func(_ context.Context, _ *std.Tx) (*abci.ResponseDeliverTx, error) {
+					simulations++
+
+					return &abci.ResponseDeliverTx{}, nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					broadcasts++
+
+					return &coreTypes.ResultBroadcastTxCommit{}, nil
+				},
+			}
+		)
+
+		f := newSimulatingFaucet(t, true, mockClient)
+
+		response := drip(f)
+		require.Nil(t, response.Error)
+
+		assert.Equal(t, 1, simulations)
+		assert.Equal(t, 1, broadcasts)
+	})
+
+	t.Run("simulation disabled", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			simulations int
+
+			mockClient = &mockClient{
+				simulateTransactionFn: func(_ context.Context, _ *std.Tx) (*abci.ResponseDeliverTx, error) {
+					simulations++
+
+					return &abci.ResponseDeliverTx{}, nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					return &coreTypes.ResultBroadcastTxCommit{}, nil
+				},
+			}
+		)
+
+		f := newSimulatingFaucet(t, false, mockClient)
+
+		response := drip(f)
+		require.Nil(t, response.Error)
+
+		assert.Zero(t, simulations)
+	})
+}
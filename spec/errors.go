@@ -5,4 +5,5 @@ const (
 	MethodNotFoundErrorCode int = -32601
 	InvalidRequestErrorCode int = -32600
 	ServerErrorCode         int = -32000
+	ServerBusyErrorCode     int = -32001
 )
@@ -0,0 +1,119 @@
+package faucet
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	DripStatusPending   = "pending"   // the drip transaction is not yet committed
+	DripStatusCommitted = "committed" // the drip transaction is committed and successful
+	DripStatusFailed    = "failed"    // the drip transaction is committed, but failed
+)
+
+// pendingTxRetention is the period a broadcast transaction
+// is considered pending, before it is dropped from tracking
+const pendingTxRetention = time.Hour
+
+var errUnknownTransaction = errors.New("unknown drip transaction")
+
+// DripStatus is the status of a drip transaction
+type DripStatus struct {
+	Status  string `json:"status"`
+	Hash    string `json:"hash"`
+	Error   string `json:"error,omitempty"`
+	Height  int64  `json:"height,omitempty"`
+	GasUsed int64  `json:"gasUsed,omitempty"`
+}
+
+// txTracker keeps track of the drip transactions
+// that were broadcast, but are possibly not yet committed
+type txTracker struct {
+	pending   map[string]time.Time // tx hash -> broadcast time
+	retention time.Duration
+
+	mux sync.Mutex
+}
+
+// newTxTracker creates a new pending transaction tracker
+func newTxTracker(retention time.Duration) *txTracker {
+	return &txTracker{
+		pending:   make(map[string]time.Time),
+		retention: retention,
+	}
+}
+
+// track marks the transaction as pending
+func (t *txTracker) track(hash []byte) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	now := time.Now()
+
+	// Drop the transactions that have been
+	// pending for too long (likely evicted)
+	for key, broadcastAt := range t.pending {
+		if now.Sub(broadcastAt) > t.retention {
+			delete(t.pending, key)
+		}
+	}
+
+	t.pending[string(hash)] = now
+}
+
+// isPending checks if the transaction is tracked as pending
+func (t *txTracker) isPending(hash []byte) bool {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	broadcastAt, exists := t.pending[string(hash)]
+
+	return exists && time.Since(broadcastAt) <= t.retention
+}
+
+// untrack removes the transaction from tracking
+func (t *txTracker) untrack(hash []byte) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	delete(t.pending, string(hash))
+}
+
+// getDripStatus fetches the status of the drip transaction
+func (f *Faucet) getDripStatus(ctx context.Context, hash []byte) (*DripStatus, error) {
+	encodedHash := base64.StdEncoding.EncodeToString(hash)
+
+	result, err := f.client.GetTransaction(ctx, hash)
+	if err != nil {
+		// The node doesn't have the transaction committed
+		if f.txTracker.isPending(hash) {
+			return &DripStatus{
+				Status: DripStatusPending,
+				Hash:   encodedHash,
+			}, nil
+		}
+
+		return nil, fmt.Errorf("%w, %w", errUnknownTransaction, err)
+	}
+
+	// The transaction is committed, no need to track it
+	f.txTracker.untrack(hash)
+
+	status := &DripStatus{
+		Status:  DripStatusCommitted,
+		Hash:    encodedHash,
+		Height:  result.Height,
+		GasUsed: result.TxResult.GasUsed,
+	}
+
+	if result.TxResult.IsErr() {
+		status.Status = DripStatusFailed
+		status.Error = result.TxResult.Error.Error()
+	}
+
+	return status, nil
+}
@@ -0,0 +1,262 @@
+package faucet
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/faucet/spec"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxTracker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pending transaction", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := newTxTracker(time.Hour)
+		tracker.track([]byte("hash"))
+
+		assert.True(t, tracker.isPending([]byte("hash")))
+		assert.False(t, tracker.isPending([]byte("other hash")))
+
+		tracker.untrack([]byte("hash"))
+
+		assert.False(t, tracker.isPending([]byte("hash")))
+	})
+
+	t.Run("expired transaction", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := newTxTracker(0)
+		tracker.track([]byte("hash"))
+
+		time.Sleep(time.Millisecond)
+
+		assert.False(t, tracker.isPending([]byte("hash")))
+
+		// Expired transactions are dropped
+		tracker.track([]byte("other hash"))
+
+		assert.Len(t, tracker.pending, 1)
+	})
+}
+
+func TestFaucet_DripStatus(t *testing.T) {
+	t.Parallel()
+
+	var (
+		txHash        = []byte("hash")
+		encodedTxHash = base64.StdEncoding.EncodeToString(txHash)
+		notFoundErr   = errors.New("tx not found")
+	)
+
+	// getStatus executes the drip status request
+	getStatus := func(t *testing.T, f *Faucet, params []any) *spec.BaseJSONResponse {
+		t.Helper()
+
+		return f.defaultHTTPHandler(
+			context.Background(),
+			spec.NewJSONRequest(0, DripStatusMethod, params),
+		)
+	}
+
+	t.Run("invalid hash", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := NewFaucet(&mockEstimator{}, &mockClient{})
+		require.NoError(t, err)
+
+		response := getStatus(t, f, []any{"not base64!"})
+
+		require.NotNil(t, response.Error)
+		assert.Equal(t, spec.InvalidParamsErrorCode, response.Error.Code)
+		assert.Contains(t, response.Error.Message, errInvalidTxHash.Error())
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockClient{
+			getTransactionFn: func(_ context.Context, _ []byte) (*coreTypes.ResultTx, error) {
+				return nil, notFoundErr
+			},
+		}
+
+		f, err := NewFaucet(&mockEstimator{}, mockClient)
+		require.NoError(t, err)
+
+		response := getStatus(t, f, []any{encodedTxHash})
+
+		require.NotNil(t, response.Error)
+		assert.Contains(t, response.Error.Message, errUnknownTransaction.Error())
+	})
+
+	t.Run("pending transaction", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockClient{
+			getTransactionFn: func(_ context.Context, _ []byte) (*coreTypes.ResultTx, error) {
+				return nil, notFoundErr
+			},
+		}
+
+		f, err := NewFaucet(&mockEstimator{}, mockClient)
+		require.NoError(t, err)
+
+		f.txTracker.track(txHash)
+
+		response := getStatus(t, f, []any{encodedTxHash})
+		require.Nil(t, response.Error)
+
+		assert.Equal(t, &DripStatus{
+			Status: DripStatusPending,
+			Hash:   encodedTxHash,
+		}, response.Result)
+	})
+
+	t.Run("committed transaction", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockClient{
+			getTransactionFn: func(_ context.Context, hash []byte) (*coreTypes.ResultTx, error) {
+				require.Equal(t, txHash, hash)
+
+				return &coreTypes.ResultTx{
+					Hash:   hash,
+					Height: 10,
+					TxResult: abci.ResponseDeliverTx{
+						GasUsed: 1000,
+					},
+				}, nil
+			},
+		}
+
+		f, err := NewFaucet(&mockEstimator{}, mockClient)
+		require.NoError(t, err)
+
+		f.txTracker.track(txHash)
+
+		response := getStatus(t, f, []any{encodedTxHash})
+		require.Nil(t, response.Error)
+
+		assert.Equal(t, &DripStatus{
+			Status:  DripStatusCommitted,
+			Hash:    encodedTxHash,
+			Height:  10,
+			GasUsed: 1000,
+		}, response.Result)
+
+		// Make sure the transaction is no longer tracked
+		assert.False(t, f.txTracker.isPending(txHash))
+	})
+
+	t.Run("failed transaction", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			deliverErr = std.InsufficientFundsError{}
+
+			mockClient = &mockClient{
+				getTransactionFn: func(_ context.Context, hash []byte) (*coreTypes.ResultTx, error) {
+					return &coreTypes.ResultTx{
+						Hash:   hash,
+						Height: 10,
+						TxResult: abci.ResponseDeliverTx{
+							ResponseBase: abci.ResponseBase{
+								Error: deliverErr,
+							},
+							GasUsed: 1000,
+						},
+					}, nil
+				},
+			}
+		)
+
+		f, err := NewFaucet(&mockEstimator{}, mockClient)
+		require.NoError(t, err)
+
+		response := getStatus(t, f, []any{encodedTxHash})
+		require.Nil(t, response.Error)
+
+		assert.Equal(t, &DripStatus{
+			Status:  DripStatusFailed,
+			Hash:    encodedTxHash,
+			Error:   deliverErr.Error(),
+			Height:  10,
+			GasUsed: 1000,
+		}, response.Result)
+	})
+
+	t.Run("sync drip returns the hash", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			sendAmount = std.NewCoins(std.NewCoin("ugnot", 10))
+
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, _ crypto.Address) (std.Account, error) {
+					return &mockAccount{
+						getCoinsFn: func() std.Coins {
+							return sendAmount
+						},
+					}, nil
+				},
+				sendTransactionSyncFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTx, error) {
+					return &coreTypes.ResultBroadcastTx{
+						Hash: txHash,
+					}, nil
+				},
+			}
+			mockEstimator = &mockEstimator{
+				estimateGasFeeFn: func(_ int64) std.Coin {
+					return std.NewCoin("ugnot", 0)
+				},
+			}
+			mockKeyring = &mockKeyring{
+				getSignerFn: func(_ crypto.Address) keyring.Signer {
+					return &mockSigner{}
+				},
+				getAddressesFn: func() []crypto.Address {
+					return []crypto.Address{{0}}
+				},
+			}
+		)
+
+		cfg := config.DefaultConfig()
+		cfg.MaxSendAmount = sendAmount.String()
+		cfg.BroadcastMode = config.BroadcastModeSync
+
+		f, err := NewFaucet(mockEstimator, mockClient, WithConfig(cfg), WithKeyring(mockKeyring))
+		require.NoError(t, err)
+
+		response := f.defaultHTTPHandler(
+			context.Background(),
+			spec.NewJSONRequest(
+				0,
+				DefaultDripMethod,
+				[]any{crypto.Address{1}.String()},
+			),
+		)
+		require.Nil(t, response.Error)
+
+		// Make sure the hash is returned, and tracked
+		result, ok := response.Result.(*DripResult)
+		require.True(t, ok)
+
+		assert.Equal(t, DripStatusPending, result.Status)
+		assert.Equal(t, encodedTxHash, result.Hash)
+		assert.Zero(t, result.Height)
+		assert.True(t, f.txTracker.isPending(txHash))
+	})
+}
@@ -1,61 +1,300 @@
 package faucet
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
+	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/policy"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
-var errNoFundedAccount = errors.New("no funded account found")
+var (
+	errNoFundedAccount = errors.New("no funded account found")
+	errFeeNotCovered   = errors.New("account can't cover the transaction fee")
+)
+
+// transferFunds transfers funds to the given address, through the drip queue.
+// If batching is enabled, the transfer is executed together with
+// other transfers in the same batch, as a single transaction.
+// In sync broadcast mode, the returned transaction is still pending
+func (f *Faucet) transferFunds(ctx context.Context, address crypto.Address, amount std.Coins) (*txResult, error) {
+	t := transfer{
+		to:     address,
+		amount: amount,
+		info:   getDripInfo(ctx),
+	}
+
+	if f.batcher != nil {
+		return f.batcher.submit(ctx, t)
+	}
+
+	return f.queue.submit(ctx, []transfer{t})
+}
+
+// executeTransfers executes the given transfers
+// as a single (multi-message) transaction
+func (f *Faucet) executeTransfers(ctx context.Context, transfers []transfer) (*txResult, error) {
+	// Keep the faucet accounts signing, until the transfers are done
+	defer f.keyring.acquire()()
+
+	// Calculate the total amount the transfers spend
+	amount := totalAmount(transfers)
 
-// transferFunds transfers funds to the given address
-func (f *Faucet) transferFunds(address crypto.Address, amount std.Coins) error {
-	// Find an account that has balance to cover the transfer
-	fundAccount, err := f.findFundedAccount(amount)
+	if f.config.DripMessage == config.DripMessageCall && !f.config.RealmCall.AttachAmount {
+		// Realm call drips don't spend
+		// the drip amount, only the fee
+		amount = std.Coins{}
+	}
+
+	// Find an account that has balance to cover the transfers
+	fundAccount, err := f.findFundedAccount(ctx, amount)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Prepare the transaction
-	pCfg := PrepareCfg{
-		FromAddress: fundAccount.GetAddress(),
-		ToAddress:   address,
-		SendAmount:  amount,
+	return f.sendTransfers(ctx, fundAccount, transfers, f.prepareTxMsgsFn)
+}
+
+// sendTransfers executes the given transfers from the faucet account,
+// as a single (multi-message) transaction with the given messages
+func (f *Faucet) sendTransfers(
+	ctx context.Context,
+	fundAccount std.Account,
+	transfers []transfer,
+	prepareTxMsgsFn PrepareTxMessagesFn,
+) (*txResult, error) {
+	// Prepare the transaction, and calculate
+	// the total amount the messages spend
+	var (
+		msgs   = make([]std.Msg, 0, len(transfers))
+		amount = std.Coins{}
+	)
+
+	for _, t := range transfers {
+		pCfg := PrepareCfg{
+			FromAddress: fundAccount.GetAddress(),
+			ToAddress:   t.to,
+			SendAmount:  t.amount,
+		}
+
+		transferMsgs := prepareTxMsgsFn(pCfg)
+
+		msgs = append(msgs, transferMsgs...)
+		amount = amount.Add(spentAmount(transferMsgs, t))
+	}
+
+	memo, err := f.prepareMemo(transfers)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := f.keyring.GetSigner(fundAccount.GetAddress())
+	tx := prepareTransaction(ctx, f.estimator, signer.PubKey(), memo, msgs...)
+
+	// The funded account was found with the min fee estimate,
+	// so make sure it also covers the fee for the final gas wanted
+	if err = policy.CheckFunding(fundAccount.GetCoins(), amount, tx.Fee.GasFee); err != nil {
+		return nil, fmt.Errorf("%w, %w", errFeeNotCovered, err)
 	}
-	tx := prepareTransaction(f.estimator, f.prepareTxMsgFn(pCfg))
+
+	// Lock the account sequence, so no other
+	// drip signs with the same sequence
+	accountSequence := f.sequencer.lock(fundAccount.GetAddress())
+	defer accountSequence.unlock()
 
 	// Sign the transaction
 	sCfg := signCfg{
 		chainID:       f.config.ChainID,
 		accountNumber: fundAccount.GetAccountNumber(),
-		sequence:      fundAccount.GetSequence(),
+		sequence:      accountSequence.next(fundAccount.GetSequence()),
+	}
+
+	if err = signTransaction(ctx, tx, signer, sCfg); err != nil {
+		return nil, err
+	}
+
+	// Simulate the transaction, if enabled,
+	// so drips that would fail are never broadcast
+	if f.config.SimulateTransactions {
+		if err := simulateTransaction(ctx, f.client, tx); err != nil {
+			// Nothing was broadcast, so the sequence was not used up
+			return nil, err
+		}
+	}
+
+	// Broadcast the transaction, bumping the gas fee
+	// if it's rejected for an insufficient fee
+	result, err := f.broadcast(ctx, tx)
+	bumped := false
+
+	for f.feeBumper != nil && errors.Is(err, std.InsufficientFeeError{}) {
+		bumpedFee, ok := f.feeBumper.bump(tx.Fee.GasFee)
+		if !ok {
+			break
+		}
+
+		// Make sure the account still covers the bumped fee
+		if fundErr := policy.CheckFunding(fundAccount.GetCoins(), amount, bumpedFee); fundErr != nil {
+			f.logger.Error(
+				"unable to cover bumped gas fee",
+				"bumped",
+				bumpedFee.String(),
+				"error",
+				fundErr,
+			)
+
+			break
+		}
+
+		f.logger.Info(
+			"bumping insufficient gas fee",
+			"fee",
+			tx.Fee.GasFee.String(),
+			"bumped",
+			bumpedFee.String(),
+		)
+
+		// Re-sign the transaction with the bumped fee.
+		// The rejected transaction didn't use up the sequence
+		tx.Fee.GasFee = bumpedFee
+		tx.Signatures = nil
+
+		if signErr := signTransaction(ctx, tx, signer, sCfg); signErr != nil {
+			return nil, signErr
+		}
+
+		result, err = f.broadcast(ctx, tx)
+		bumped = true
+	}
+
+	// Update the local account sequence
+	f.updateSequence(ctx, fundAccount.GetAddress(), accountSequence, err)
+
+	if err != nil {
+		// The cached account state can't be trusted anymore
+		if f.accountCache != nil {
+			f.accountCache.invalidate(fundAccount.GetAddress())
+		}
+
+		return nil, err
 	}
 
-	if err := signTransaction(
-		tx,
-		f.keyring.GetKey(fundAccount.GetAddress()),
-		sCfg,
-	); err != nil {
-		return err
+	// Keep the accepted bumped fee for subsequent drips
+	if bumped {
+		f.feeBumper.accept(tx.Fee.GasFee, tx.Fee.GasWanted)
 	}
 
-	// Broadcast the transaction
-	return broadcastTransaction(f.client, tx)
+	result.from = fundAccount.GetAddress()
+	result.fee = tx.Fee.GasFee
+	result.gasWanted = tx.Fee.GasWanted
+
+	// Update the cached account state,
+	// without waiting for the next refresh
+	f.applyTransfer(fundAccount.GetAddress(), amount.Add(std.NewCoins(tx.Fee.GasFee)), sCfg.sequence)
+
+	return result, nil
+}
+
+// broadcast broadcasts the signed transaction,
+// using the configured broadcast mode
+func (f *Faucet) broadcast(ctx context.Context, tx *std.Tx) (*txResult, error) {
+	if f.config.BroadcastMode == config.BroadcastModeSync {
+		response, err := broadcastTransactionSync(ctx, f.client, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		f.txTracker.track(response.Hash)
+
+		return &txResult{
+			status: DripStatusPending,
+			hash:   response.Hash,
+		}, nil
+	}
+
+	response, err := broadcastTransaction(ctx, f.client, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txResult{
+		status:  DripStatusCommitted,
+		hash:    response.Hash,
+		height:  response.Height,
+		gasUsed: response.DeliverTx.GasUsed,
+	}, nil
+}
+
+// updateSequence updates the local account sequence,
+// based on the outcome of the transaction broadcast
+func (f *Faucet) updateSequence(
+	ctx context.Context,
+	address crypto.Address,
+	accountSequence *accountSequence,
+	broadcastErr error,
+) {
+	switch {
+	case broadcastErr == nil, errors.Is(broadcastErr, errDeliverTxFailed):
+		// The transaction passed the initial validation,
+		// so the sequence was used up
+		accountSequence.increment()
+	case errors.Is(broadcastErr, std.UnauthorizedError{}):
+		// The signature was rejected, which is how the chain
+		// reports a sequence that's out of sync with the chain
+		// (for example, after a pending transaction was dropped)
+		account, err := f.client.GetAccount(ctx, address)
+		if err != nil {
+			f.logger.Error(
+				"unable to resync account sequence",
+				"address",
+				address.String(),
+				"error",
+				err,
+			)
+
+			accountSequence.reset()
+
+			return
+		}
+
+		accountSequence.sync(account.GetSequence())
+	case errors.Is(broadcastErr, errCheckTxFailed):
+		// The transaction was rejected before
+		// the sequence was used up
+	default:
+		// The outcome of the broadcast is unknown,
+		// so rely on the chain sequence going forward
+		accountSequence.reset()
+	}
 }
 
 // findFundedAccount finds an account
-// whose balance is enough to cover the send amount
-func (f *Faucet) findFundedAccount(amount std.Coins) (std.Account, error) {
+// whose balance is enough to cover the send amount.
+// If there are multiple such accounts, the account
+// selection strategy decides which one is used
+func (f *Faucet) findFundedAccount(ctx context.Context, amount std.Coins) (std.Account, error) {
 	// A funded account is an account that can
-	// cover the initial transfer fee, as well
+	// cover the min transfer fee, as well
 	// as the send amount
-	estimatedFee := f.estimator.EstimateGasFee()
-	requiredFunds := amount.Add(std.NewCoins(estimatedFee))
+	estimatedFee := f.estimator.EstimateGasFee(0)
+
+	// The treasury account only tops up
+	// the other faucet accounts
+	treasury, hasTreasury := f.treasuryAddress()
+
+	addresses := f.keyring.GetAddresses()
+	fundedAccounts := make([]std.Account, 0, len(addresses))
 
-	for _, address := range f.keyring.GetAddresses() {
-		// Fetch the account
-		account, err := f.client.GetAccount(address)
+	for _, address := range addresses {
+		if hasTreasury && address == treasury {
+			continue
+		}
+
+		// Fetch the account, from the cache if possible
+		account, err := f.getAccount(ctx, address)
 		if err != nil {
 			f.logger.Error(
 				"unable to fetch account",
@@ -71,23 +310,37 @@ func (f *Faucet) findFundedAccount(amount std.Coins) (std.Account, error) {
 		// Fetch the balance
 		balance := account.GetCoins()
 
-		// Make sure there are enough funds
-		if balance.IsAllLT(requiredFunds) {
+		// Make sure there are enough funds, for each denomination
+		if err := policy.CheckFunding(balance, amount, estimatedFee); err != nil {
 			f.logger.Error(
 				"account cannot serve requests",
 				"address",
 				address.String(),
 				"balance",
 				balance.String(),
-				"amount",
-				requiredFunds,
+				"error",
+				err,
 			)
 
 			continue
 		}
 
-		return account, nil
+		fundedAccounts = append(fundedAccounts, account)
+	}
+
+	if len(fundedAccounts) == 0 {
+		return nil, errNoFundedAccount
+	}
+
+	return f.selector.Select(fundedAccounts, amount), nil
+}
+
+// totalAmount calculates the total amount of the given transfers
+func totalAmount(transfers []transfer) std.Coins {
+	amount := std.Coins{}
+	for _, t := range transfers {
+		amount = amount.Add(t.amount)
 	}
 
-	return nil, errNoFundedAccount
+	return amount
 }
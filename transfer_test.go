@@ -1,13 +1,19 @@
 package faucet
 
 import (
+	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gnolang/faucet/config"
+	"github.com/gnolang/faucet/keyring"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	coreTypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,12 +30,12 @@ func TestFaucet_TransferFunds(t *testing.T) {
 			amount   = std.NewCoins(std.NewCoin("ugnot", 1))
 
 			mockClient = &mockClient{
-				getAccountFn: func(_ crypto.Address) (std.Account, error) {
+				getAccountFn: func(_ context.Context, _ crypto.Address) (std.Account, error) {
 					return nil, fetchErr
 				},
 			}
 			mockEstimator = &mockEstimator{
-				estimateGasFeeFn: func() std.Coin {
+				estimateGasFeeFn: func(_ int64) std.Coin {
 					return std.NewCoin("ugnot", 0)
 				},
 			}
@@ -45,7 +51,8 @@ func TestFaucet_TransferFunds(t *testing.T) {
 		require.NotNil(t, f)
 
 		// Attempt the transfer
-		assert.ErrorIs(t, f.transferFunds(crypto.Address{}, amount), errNoFundedAccount)
+		_, err = f.transferFunds(context.Background(), crypto.Address{}, amount)
+		assert.ErrorIs(t, err, errNoFundedAccount)
 	})
 
 	t.Run("no funded accounts", func(t *testing.T) {
@@ -56,7 +63,7 @@ func TestFaucet_TransferFunds(t *testing.T) {
 			accountBalance = std.NewCoins(std.NewCoin("ugnot", 5))
 
 			mockClient = &mockClient{
-				getAccountFn: func(_ crypto.Address) (std.Account, error) {
+				getAccountFn: func(_ context.Context, _ crypto.Address) (std.Account, error) {
 					return &mockAccount{
 						getCoinsFn: func() std.Coins {
 							return accountBalance // less than the send amount
@@ -65,7 +72,7 @@ func TestFaucet_TransferFunds(t *testing.T) {
 				},
 			}
 			mockEstimator = &mockEstimator{
-				estimateGasFeeFn: func() std.Coin {
+				estimateGasFeeFn: func(_ int64) std.Coin {
 					return std.NewCoin("ugnot", 0)
 				},
 			}
@@ -85,7 +92,8 @@ func TestFaucet_TransferFunds(t *testing.T) {
 		require.NotNil(t, f)
 
 		// Attempt the transfer
-		assert.ErrorIs(t, f.transferFunds(crypto.Address{}, sendAmount), errNoFundedAccount)
+		_, err = f.transferFunds(context.Background(), crypto.Address{}, sendAmount)
+		assert.ErrorIs(t, err, errNoFundedAccount)
 	})
 
 	t.Run("unable to sign transaction", func(t *testing.T) {
@@ -97,7 +105,7 @@ func TestFaucet_TransferFunds(t *testing.T) {
 			signErr = errors.New("unable to sign transaction")
 
 			mockClient = &mockClient{
-				getAccountFn: func(_ crypto.Address) (std.Account, error) {
+				getAccountFn: func(_ context.Context, _ crypto.Address) (std.Account, error) {
 					return &mockAccount{
 						getCoinsFn: func() std.Coins {
 							return sendAmount // can cover the send amount
@@ -106,18 +114,18 @@ func TestFaucet_TransferFunds(t *testing.T) {
 				},
 			}
 			mockEstimator = &mockEstimator{
-				estimateGasFeeFn: func() std.Coin {
+				estimateGasFeeFn: func(_ int64) std.Coin {
 					return std.NewCoin("ugnot", 0)
 				},
 			}
-			mockPrivKey = &mockPrivKey{
+			mockSigner = &mockSigner{
 				signFn: func(_ []byte) ([]byte, error) {
 					return nil, signErr
 				},
 			}
 			mockKeyring = &mockKeyring{
-				getKeyFn: func(_ crypto.Address) crypto.PrivKey {
-					return mockPrivKey
+				getSignerFn: func(_ crypto.Address) keyring.Signer {
+					return mockSigner
 				},
 				getAddressesFn: func() []crypto.Address {
 					return []crypto.Address{
@@ -135,16 +143,15 @@ func TestFaucet_TransferFunds(t *testing.T) {
 			mockEstimator,
 			mockClient,
 			WithConfig(cfg),
+			WithKeyring(mockKeyring),
 		)
 
-		// Set the keyring
-		f.keyring = mockKeyring
-
 		require.NoError(t, err)
 		require.NotNil(t, f)
 
 		// Attempt the transfer
-		assert.ErrorIs(t, f.transferFunds(crypto.Address{}, sendAmount), signErr)
+		_, err = f.transferFunds(context.Background(), crypto.Address{}, sendAmount)
+		assert.ErrorIs(t, err, signErr)
 	})
 
 	t.Run("valid asset transfer", func(t *testing.T) {
@@ -167,30 +174,30 @@ func TestFaucet_TransferFunds(t *testing.T) {
 			}
 
 			mockClient = &mockClient{
-				getAccountFn: func(_ crypto.Address) (std.Account, error) {
+				getAccountFn: func(_ context.Context, _ crypto.Address) (std.Account, error) {
 					return &mockAccount{
 						getCoinsFn: func() std.Coins {
 							return sendAmount
 						},
 					}, nil
 				},
-				sendTransactionCommitFn: func(_ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+				sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
 					return response, nil
 				},
 			}
 			mockEstimator = &mockEstimator{
-				estimateGasFeeFn: func() std.Coin {
+				estimateGasFeeFn: func(_ int64) std.Coin {
 					return std.NewCoin("ugnot", 0)
 				},
 			}
-			mockPrivKey = &mockPrivKey{
+			mockSigner = &mockSigner{
 				signFn: func(_ []byte) ([]byte, error) {
 					return []byte("signature"), nil
 				},
 			}
 			mockKeyring = &mockKeyring{
-				getKeyFn: func(_ crypto.Address) crypto.PrivKey {
-					return mockPrivKey
+				getSignerFn: func(_ crypto.Address) keyring.Signer {
+					return mockSigner
 				},
 				getAddressesFn: func() []crypto.Address {
 					return []crypto.Address{
@@ -208,14 +215,525 @@ func TestFaucet_TransferFunds(t *testing.T) {
 			mockEstimator,
 			mockClient,
 			WithConfig(cfg),
+			WithKeyring(mockKeyring),
 		)
 
-		f.keyring = mockKeyring
-
 		require.NoError(t, err)
 		require.NotNil(t, f)
 
 		// Attempt the transfer
-		assert.NoError(t, f.transferFunds(crypto.Address{}, sendAmount))
+		_, err = f.transferFunds(context.Background(), crypto.Address{}, sendAmount)
+		assert.NoError(t, err)
+	})
+
+	t.Run("multi-message transfer", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			capturedTx *std.Tx
+
+			sendAmount = std.NewCoins(std.NewCoin("ugnot", 10))
+			pkgPath    = "gno.land/r/demo/starter"
+
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, _ crypto.Address) (std.Account, error) {
+					return &mockAccount{
+						getCoinsFn: func() std.Coins {
+							return sendAmount
+						},
+					}, nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, tx *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					capturedTx = tx
+
+					return &coreTypes.ResultBroadcastTxCommit{}, nil
+				},
+			}
+			mockEstimator = &mockEstimator{
+				estimateGasFeeFn: func(_ int64) std.Coin {
+					return std.NewCoin("ugnot", 0)
+				},
+			}
+			mockKeyring = &mockKeyring{
+				getSignerFn: func(_ crypto.Address) keyring.Signer {
+					return &mockSigner{}
+				},
+				getAddressesFn: func() []crypto.Address {
+					return []crypto.Address{
+						{0}, // 1 account
+					}
+				},
+			}
+
+			prepareTxMsgsFn = func(cfg PrepareCfg) []std.Msg {
+				return []std.Msg{
+					defaultPrepareTxMessage(cfg),
+					vm.MsgCall{
+						Caller:  cfg.FromAddress,
+						PkgPath: pkgPath,
+						Func:    "Mint",
+						Args:    []string{cfg.ToAddress.String()},
+					},
+				}
+			}
+		)
+
+		// Create faucet
+		cfg := config.DefaultConfig()
+		cfg.MaxSendAmount = sendAmount.String()
+
+		f, err := NewFaucet(
+			mockEstimator,
+			mockClient,
+			WithConfig(cfg),
+			WithPrepareTxMessagesFn(prepareTxMsgsFn),
+			WithKeyring(mockKeyring),
+		)
+		require.NoError(t, err)
+
+		// Attempt the transfer
+		_, err = f.transferFunds(context.Background(), crypto.Address{1}, sendAmount)
+		require.NoError(t, err)
+
+		// Make sure both messages were sent in the same transaction
+		require.NotNil(t, capturedTx)
+		require.Len(t, capturedTx.Msgs, 2)
+
+		_, ok := capturedTx.Msgs[0].(bank.MsgSend)
+		assert.True(t, ok)
+
+		msgCall, ok := capturedTx.Msgs[1].(vm.MsgCall)
+		require.True(t, ok)
+
+		assert.Equal(t, pkgPath, msgCall.PkgPath)
+	})
+
+	t.Run("concurrent transfers use distinct sequences", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			numTransfers = 10
+			sendAmount   = std.NewCoins(std.NewCoin("ugnot", 10))
+
+			signedPayloads = make(map[string]struct{})
+			mux            sync.Mutex
+
+			response = &coreTypes.ResultBroadcastTxCommit{}
+
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, _ crypto.Address) (std.Account, error) {
+					return &mockAccount{
+						getCoinsFn: func() std.Coins {
+							return sendAmount
+						},
+						getSequenceFn: func() uint64 {
+							return 0 // the chain is lagging behind
+						},
+					}, nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					return response, nil
+				},
+			}
+			mockEstimator = &mockEstimator{
+				estimateGasFeeFn: func(_ int64) std.Coin {
+					return std.NewCoin("ugnot", 0)
+				},
+			}
+			mockSigner = &mockSigner{
+				signFn: func(signBytes []byte) ([]byte, error) {
+					mux.Lock()
+					defer mux.Unlock()
+
+					signedPayloads[string(signBytes)] = struct{}{}
+
+					return []byte("signature"), nil
+				},
+			}
+			mockKeyring = &mockKeyring{
+				getSignerFn: func(_ crypto.Address) keyring.Signer {
+					return mockSigner
+				},
+				getAddressesFn: func() []crypto.Address {
+					return []crypto.Address{
+						{0}, // 1 account
+					}
+				},
+			}
+		)
+
+		// Create faucet
+		cfg := config.DefaultConfig()
+		cfg.MaxSendAmount = sendAmount.String()
+
+		f, err := NewFaucet(
+			mockEstimator,
+			mockClient,
+			WithConfig(cfg),
+			WithKeyring(mockKeyring),
+		)
+
+		require.NoError(t, err)
+		require.NotNil(t, f)
+
+		// Run the transfers in parallel
+		var wg sync.WaitGroup
+
+		for range numTransfers {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, transferErr := f.transferFunds(context.Background(), crypto.Address{}, sendAmount)
+				assert.NoError(t, transferErr)
+			}()
+		}
+
+		wg.Wait()
+
+		// Make sure every transfer was signed with a different sequence
+		assert.Len(t, signedPayloads, numTransfers)
+	})
+
+	t.Run("unauthorized sequence resync", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			sendAmount    = std.NewCoins(std.NewCoin("ugnot", 10))
+			chainSequence = uint64(0)
+
+			capturedSignBytes [][]byte
+
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, _ crypto.Address) (std.Account, error) {
+					return &mockAccount{
+						getCoinsFn: func() std.Coins {
+							return sendAmount
+						},
+						getSequenceFn: func() uint64 {
+							return chainSequence
+						},
+					}, nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					return &coreTypes.ResultBroadcastTxCommit{
+						CheckTx: abci.ResponseCheckTx{
+							ResponseBase: abci.ResponseBase{
+								Error: std.UnauthorizedError{},
+							},
+						},
+					}, nil
+				},
+			}
+			mockEstimator = &mockEstimator{
+				estimateGasFeeFn: func(_ int64) std.Coin {
+					return std.NewCoin("ugnot", 0)
+				},
+			}
+			mockSigner = &mockSigner{
+				signFn: func(signBytes []byte) ([]byte, error) {
+					capturedSignBytes = append(capturedSignBytes, signBytes)
+
+					return []byte("signature"), nil
+				},
+			}
+			mockKeyring = &mockKeyring{
+				getSignerFn: func(_ crypto.Address) keyring.Signer {
+					return mockSigner
+				},
+				getAddressesFn: func() []crypto.Address {
+					return []crypto.Address{
+						{0}, // 1 account
+					}
+				},
+			}
+		)
+
+		// Create faucet
+		cfg := config.DefaultConfig()
+		cfg.MaxSendAmount = sendAmount.String()
+
+		f, err := NewFaucet(
+			mockEstimator,
+			mockClient,
+			WithConfig(cfg),
+			WithKeyring(mockKeyring),
+		)
+
+		require.NoError(t, err)
+		require.NotNil(t, f)
+
+		// Bump the local sequence ahead of the chain
+		accountSequence := f.sequencer.lock(crypto.Address{0})
+		accountSequence.sync(5)
+		accountSequence.unlock()
+
+		// Attempt the transfer, which fails on the sequence
+		_, err = f.transferFunds(context.Background(), crypto.Address{}, sendAmount)
+		assert.ErrorIs(t, err, std.UnauthorizedError{})
+
+		// Make sure the local sequence was resynced with the chain
+		accountSequence = f.sequencer.lock(crypto.Address{0})
+		defer accountSequence.unlock()
+
+		assert.Equal(t, chainSequence, accountSequence.next(chainSequence))
+		require.Len(t, capturedSignBytes, 1)
+	})
+
+	t.Run("transfers spread across funded accounts", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			sendAmount = std.NewCoins(std.NewCoin("ugnot", 10))
+			addresses  = []crypto.Address{{1}, {2}}
+
+			usedAccounts []crypto.Address
+
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
+					return &mockAccount{
+						getAddressFn: func() crypto.Address {
+							return address
+						},
+						getCoinsFn: func() std.Coins {
+							return sendAmount
+						},
+					}, nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					return &coreTypes.ResultBroadcastTxCommit{}, nil
+				},
+			}
+			mockEstimator = &mockEstimator{
+				estimateGasFeeFn: func(_ int64) std.Coin {
+					return std.NewCoin("ugnot", 0)
+				},
+			}
+			mockKeyring = &mockKeyring{
+				getSignerFn: func(address crypto.Address) keyring.Signer {
+					return &mockSigner{
+						signFn: func(_ []byte) ([]byte, error) {
+							usedAccounts = append(usedAccounts, address)
+
+							return []byte("signature"), nil
+						},
+					}
+				},
+				getAddressesFn: func() []crypto.Address {
+					return addresses
+				},
+			}
+		)
+
+		// Create faucet
+		cfg := config.DefaultConfig()
+		cfg.MaxSendAmount = sendAmount.String()
+		cfg.AccountSelection = config.AccountSelectionRoundRobin
+
+		f, err := NewFaucet(
+			mockEstimator,
+			mockClient,
+			WithConfig(cfg),
+			WithKeyring(mockKeyring),
+		)
+
+		require.NoError(t, err)
+		require.NotNil(t, f)
+
+		// Attempt the transfers
+		for range addresses {
+			_, err = f.transferFunds(context.Background(), crypto.Address{}, sendAmount)
+			require.NoError(t, err)
+		}
+
+		// Make sure each account served a drip
+		assert.Equal(t, addresses, usedAccounts)
+	})
+
+	t.Run("fee for the final gas wanted not covered", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			broadcast  bool
+			sendAmount = std.NewCoins(std.NewCoin("ugnot", 100))
+
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
+					return std.NewBaseAccount(
+						address,
+						std.NewCoins(std.NewCoin("ugnot", 250)),
+						nil,
+						0,
+						0,
+					), nil
+				},
+				sendTransactionCommitFn: func(_ context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					broadcast = true
+
+					return &coreTypes.ResultBroadcastTxCommit{}, nil
+				},
+			}
+			mockEstimator = &mockEstimator{
+				estimateGasFeeFn: func(gasWanted int64) std.Coin {
+					// 1ugnot / 1000 gas, for at least 100000 gas
+					return std.NewCoin("ugnot", max(gasWanted, 100000)/1000)
+				},
+				estimateGasWantedFn: func(_ context.Context, _ *std.Tx) int64 {
+					return 200000
+				},
+			}
+		)
+
+		f := newTestFaucet(t, config.DefaultConfig(), mockClient, mockEstimator)
+
+		// Make sure the account covers the min fee (100ugnot),
+		// but not the fee for the final gas wanted (200ugnot)
+		_, err := f.transferFunds(context.Background(), crypto.Address{2}, sendAmount)
+		require.ErrorIs(t, err, errFeeNotCovered)
+
+		assert.False(t, broadcast)
+	})
+}
+
+func TestFaucet_FindFundedAccount(t *testing.T) {
+	t.Parallel()
+
+	var (
+		amount = std.MustParseCoins("100ugnot,100utest")
+
+		balances = map[crypto.Address]std.Coins{
+			{1}: std.MustParseCoins("1000ugnot,50utest"),  // partially underfunded
+			{2}: std.MustParseCoins("100ugnot,1000utest"), // fee not covered
+			{3}: std.MustParseCoins("101ugnot,100utest"),  // funded
+		}
+
+		mockClient = &mockClient{
+			getAccountFn: func(_ context.Context, address crypto.Address) (std.Account, error) {
+				return std.NewBaseAccount(address, balances[address], nil, 0, 0), nil
+			},
+		}
+		mockEstimator = &mockEstimator{
+			estimateGasFeeFn: func(_ int64) std.Coin {
+				return std.NewCoin("ugnot", 1)
+			},
+		}
+		mockKeyring = &mockKeyring{
+			getAddressesFn: func() []crypto.Address {
+				return []crypto.Address{{1}, {2}, {3}}
+			},
+		}
+	)
+
+	f, err := NewFaucet(mockEstimator, mockClient, WithKeyring(mockKeyring))
+	require.NoError(t, err)
+
+	// Make sure only the fully funded account is picked
+	for range 3 {
+		account, err := f.findFundedAccount(context.Background(), amount)
+		require.NoError(t, err)
+
+		assert.Equal(t, crypto.Address{3}, account.GetAddress())
+	}
+}
+
+func TestFaucet_TransferFunds_Context(t *testing.T) {
+	t.Parallel()
+
+	type ctxKey struct{}
+
+	newFaucet := func(t *testing.T, client *mockClient) *Faucet {
+		t.Helper()
+
+		var (
+			mockEstimator = &mockEstimator{
+				estimateGasFeeFn: func(_ int64) std.Coin {
+					return std.NewCoin("ugnot", 0)
+				},
+			}
+			mockKeyring = &mockKeyring{
+				getSignerFn: func(_ crypto.Address) keyring.Signer {
+					return &mockSigner{}
+				},
+				getAddressesFn: func() []crypto.Address {
+					return []crypto.Address{{1}}
+				},
+			}
+		)
+
+		f, err := NewFaucet(mockEstimator, client, WithKeyring(mockKeyring))
+		require.NoError(t, err)
+
+		return f
+	}
+
+	t.Run("request context reaches the client", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			sendAmount = std.NewCoins(std.NewCoin("ugnot", 10))
+
+			accountCtxValue any
+			sendCtxValue    any
+
+			mockClient = &mockClient{
+				getAccountFn: func(ctx context.Context, _ crypto.Address) (std.Account, error) {
+					accountCtxValue = ctx.Value(ctxKey{})
+
+					return &mockAccount{
+						getCoinsFn: func() std.Coins {
+							return sendAmount
+						},
+					}, nil
+				},
+				sendTransactionCommitFn: func(ctx context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					sendCtxValue = ctx.Value(ctxKey{})
+
+					return &coreTypes.ResultBroadcastTxCommit{}, nil
+				},
+			}
+		)
+
+		f := newFaucet(t, mockClient)
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+
+		_, err := f.transferFunds(ctx, crypto.Address{2}, sendAmount)
+		require.NoError(t, err)
+
+		assert.Equal(t, "request", accountCtxValue)
+		assert.Equal(t, "request", sendCtxValue)
+	})
+
+	t.Run("hung node", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			sendAmount = std.NewCoins(std.NewCoin("ugnot", 10))
+
+			mockClient = &mockClient{
+				getAccountFn: func(_ context.Context, _ crypto.Address) (std.Account, error) {
+					return &mockAccount{
+						getCoinsFn: func() std.Coins {
+							return sendAmount
+						},
+					}, nil
+				},
+				sendTransactionCommitFn: func(ctx context.Context, _ *std.Tx) (*coreTypes.ResultBroadcastTxCommit, error) {
+					// The node never responds
+					<-ctx.Done()
+
+					return nil, ctx.Err()
+				},
+			}
+		)
+
+		f := newFaucet(t, mockClient)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancelFn()
+
+		_, err := f.transferFunds(ctx, crypto.Address{2}, sendAmount)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
 	})
 }